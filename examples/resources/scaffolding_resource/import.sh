@@ -0,0 +1,2 @@
+# Projects can be imported by their ID, as found in the project's settings URL
+terraform import bugsnag_project.test 515fb9337c1074f6fd000002
@@ -0,0 +1,154 @@
+package bugsnag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
+)
+
+// BugsnagAPI is the part of the Bugsnag API the provider uses. It is
+// implemented by *api.Client, and can be faked to unit test resources
+// without reaching the API.
+type BugsnagAPI interface {
+	ListProjects(ctx context.Context) ([]*api.Project, error)
+	IterateProjects(ctx context.Context) api.ProjectsIterator
+	FindProjectsByName(ctx context.Context, name string) ([]*api.Project, error)
+	GetProject(ctx context.Context, projectID string) (*api.Project, error)
+	CreateProject(ctx context.Context, request *api.CreateProjectRequest) (*api.Project, error)
+	UpdateProject(ctx context.Context, projectID string, request *api.UpdateProjectRequest) (*api.Project, error)
+	DeleteProject(ctx context.Context, projectID string) error
+	RegenerateProjectAPIKey(ctx context.Context, projectID string) (string, error)
+	GetEmailNotificationSettings(ctx context.Context, projectID string) (*api.EmailNotificationSettings, error)
+	UpdateEmailNotificationSettings(ctx context.Context, projectID string, settings *api.EmailNotificationSettings) (*api.EmailNotificationSettings, error)
+	GetReopenRules(ctx context.Context, projectID string) (*api.ReopenRules, error)
+	UpdateReopenRules(ctx context.Context, projectID string, rules *api.ReopenRules) (*api.ReopenRules, error)
+	GetSpikeDetectionSettings(ctx context.Context, projectID string) (*api.SpikeDetectionSettings, error)
+	UpdateSpikeDetectionSettings(ctx context.Context, projectID string, settings *api.SpikeDetectionSettings) (*api.SpikeDetectionSettings, error)
+	GetStabilityTargets(ctx context.Context, projectID string) (*api.StabilityTargets, error)
+	UpdateStabilityTargets(ctx context.Context, projectID string, targets *api.StabilityTargets) (*api.StabilityTargets, error)
+	GetProjectStability(ctx context.Context, projectID string) (*api.ProjectStability, error)
+	GetEventUsage(ctx context.Context, projectID string) (*api.EventUsage, error)
+	ListEventFields(ctx context.Context, projectID string) ([]*api.EventField, error)
+	CreateEventField(ctx context.Context, projectID string, field *api.EventField) (*api.EventField, error)
+	UpdateEventField(ctx context.Context, projectID, displayID string, field *api.EventField) (*api.EventField, error)
+	DeleteEventField(ctx context.Context, projectID, displayID string) error
+	CreateWebhook(ctx context.Context, projectID string, request *api.CreateWebhookRequest) (*api.Webhook, error)
+	GetWebhook(ctx context.Context, projectID, webhookID string) (*api.Webhook, error)
+	UpdateWebhook(ctx context.Context, projectID, webhookID string, request *api.UpdateWebhookRequest) (*api.Webhook, error)
+	DeleteWebhook(ctx context.Context, projectID, webhookID string) error
+	GetGitLabIntegration(ctx context.Context, projectID string) (*api.GitLabIntegration, error)
+	UpdateGitLabIntegration(ctx context.Context, projectID string, integration *api.GitLabIntegration) (*api.GitLabIntegration, error)
+	DeleteGitLabIntegration(ctx context.Context, projectID string) error
+	InviteCollaborators(ctx context.Context, emails []string, projectIDs []string) error
+	AddCollaboratorProjects(ctx context.Context, collaboratorID string, projectIDs []string) error
+	InviteCollaborator(ctx context.Context, request *api.InviteCollaboratorRequest) (*api.Collaborator, error)
+	ListCollaborators(ctx context.Context) ([]*api.Collaborator, error)
+	GetCollaborator(ctx context.Context, collaboratorID string) (*api.Collaborator, error)
+	UpdateCollaborator(ctx context.Context, collaboratorID string, request *api.UpdateCollaboratorRequest) (*api.Collaborator, error)
+	DeleteCollaborator(ctx context.Context, collaboratorID string) error
+	ResendCollaboratorInvitation(ctx context.Context, collaboratorID string) error
+	SetTeamProject(ctx context.Context, teamID, projectID, permission string) (*api.TeamProject, error)
+	GetTeamProject(ctx context.Context, teamID, projectID string) (*api.TeamProject, error)
+	RemoveTeamProject(ctx context.Context, teamID, projectID string) error
+	ListAllowedEmailDomains(ctx context.Context) ([]string, error)
+	AddAllowedEmailDomain(ctx context.Context, domain string) error
+	RemoveAllowedEmailDomain(ctx context.Context, domain string) error
+	GetSSOConfiguration(ctx context.Context) (*api.SSOConfiguration, error)
+	UpdateSSOConfiguration(ctx context.Context, configuration *api.SSOConfiguration) (*api.SSOConfiguration, error)
+	DeleteSSOConfiguration(ctx context.Context) error
+	CreateAccessToken(ctx context.Context, ttl time.Duration) (*api.AccessToken, error)
+	RevokeAccessToken(ctx context.Context, id string) error
+	RateLimitWarning() (remaining int, reset time.Time, ok bool)
+	UsageSummary() (summary string, ok bool)
+}
+
+var _ BugsnagAPI = &api.Client{}
+
+// Client is the provider meta shared by every resource, data source and
+// framework feature: the API client, along with the provider-level defaults
+// they apply.
+type Client struct {
+	BugsnagAPI
+
+	// DefaultProjectType is used for projects that don't set a type.
+	DefaultProjectType string
+
+	// DefaultIgnoreOldBrowsers is used for projects created without
+	// ignore_old_browsers; nil leaves them the API's default.
+	DefaultIgnoreOldBrowsers *bool
+
+	// SkipDuplicateNameCheck makes project creation rely on the API to
+	// reject duplicate names instead of listing every project first.
+	SkipDuplicateNameCheck bool
+}
+
+// apiErrorDiagnostics turns an error returned by the client into an error
+// diagnostic, with advice for the failures users can act on.
+func apiErrorDiagnostics(summary string, err error) diag.Diagnostics {
+	detail := err.Error()
+
+	switch {
+	case errors.Is(err, api.ErrRateLimited):
+		detail += `
+
+You have reached Bugsnag's API rate limit, please wait a moment and try again.
+For further, see https://bugsnagapiv2.docs.apiary.io/#introduction/rate-limiting.`
+	case errors.Is(err, api.ErrUnauthorized):
+		detail += `
+
+Please check that your API token is valid and can access the organization.`
+	case errors.Is(err, api.ErrUnexpectedResponse):
+		var schemaErr *api.ResponseSchemaError
+		if errors.As(err, &schemaErr) {
+			detail += fmt.Sprintf(`
+
+The response of %s no longer matches what the provider expects for the %q field,
+which usually means the Bugsnag API has changed. Please check for a newer version
+of the provider, or report this issue to the provider developers.`, schemaErr.Endpoint, schemaErr.Field)
+		}
+	}
+
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   detail,
+		},
+	}
+}
+
+// rateLimitDiagnostics returns the warnings about the API usage that end an
+// operation: a warning the first time the remaining rate limit quota drops
+// to or below rate_limit_warning_threshold, and the requests sent so far
+// with usage_summary.
+func rateLimitDiagnostics(c *Client) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if remaining, reset, ok := c.RateLimitWarning(); ok {
+		resets := "soon"
+		if !reset.IsZero() {
+			resets = "at " + reset.UTC().Format(time.RFC3339)
+		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Bugsnag API rate limit nearly exhausted",
+			Detail: fmt.Sprintf(`Only %d requests remain in the current rate limit window, which resets %s.
+Consider splitting large applies, lowering -parallelism or setting requests_per_minute on the provider.`, remaining, resets),
+		})
+	}
+
+	if summary, ok := c.UsageSummary(); ok {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Bugsnag API usage",
+			Detail:   summary + "\n\nThe requests are counted since the provider started; disable usage_summary on the provider to stop reporting them.",
+		})
+	}
+
+	return diags
+}
@@ -0,0 +1,522 @@
+package bugsnag
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-bugsnag/internal/bugsnagtest"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
+)
+
+// newTestClient returns provider meta whose API client talks to the fake
+// API server.
+func newTestClient(server *bugsnagtest.Server) *Client {
+	return &Client{
+		BugsnagAPI: api.NewClient(api.ClientConfig{
+			BaseURL:             server.URL,
+			APIToken:            "token",
+			OrganizationID:      bugsnagtest.OrganizationID,
+			MaxRateLimitRetries: 1,
+		}),
+		DefaultProjectType: "go",
+	}
+}
+
+// testResourceData returns the ResourceData Terraform would apply raw with
+// to a resource whose state is prior, or to a new resource if prior is nil.
+// Unlike schema.TestResourceDataRaw, the changes are planned against the
+// prior state, with the resource's CustomizeDiff, and the raw configuration
+// write-only arguments are read from is set.
+func testResourceData(t *testing.T, r *schema.Resource, prior *terraform.InstanceState, raw map[string]interface{}, meta interface{}) *schema.ResourceData {
+	t.Helper()
+
+	configSchema := r.CoreConfigSchema()
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		t.Fatalf("unable to encode the configuration: %s", err)
+	}
+	config, err := ctyjson.Unmarshal(encoded, configSchema.ImpliedType())
+	if err != nil {
+		t.Fatalf("unable to decode the configuration: %s", err)
+	}
+
+	// as in the SDK's gRPC server, the raw configuration is passed along
+	// with the prior state
+	state := &terraform.InstanceState{}
+	if prior != nil {
+		state = prior.DeepCopy()
+	}
+	state.RawConfig = config
+
+	sm := schema.InternalMap(r.Schema)
+	diff, err := sm.Diff(context.Background(), state, terraform.NewResourceConfigShimmed(config, configSchema), r.CustomizeDiff, meta, true)
+	if err != nil {
+		t.Fatalf("unable to plan the changes: %s", err)
+	}
+	if diff == nil {
+		diff = &terraform.InstanceDiff{RawConfig: config}
+	}
+
+	d, err := sm.Data(state, diff)
+	if err != nil {
+		t.Fatalf("unable to apply the changes: %s", err)
+	}
+	if prior == nil {
+		d.MarkNewResource()
+	}
+	return d
+}
+
+// fakeAPI is an in-memory BugsnagAPI for unit testing resources.
+type fakeAPI struct {
+	projects []*api.Project
+	created  []*api.CreateProjectRequest
+	keys     int
+
+	emailNotifications map[string]*api.EmailNotificationSettings
+	reopenRules        map[string]*api.ReopenRules
+	spikeDetection     map[string]*api.SpikeDetectionSettings
+	stabilityTargets   map[string]*api.StabilityTargets
+	eventFields        map[string][]*api.EventField
+	webhooks           map[string]*api.Webhook
+	gitlab             map[string]*api.GitLabIntegration
+	collaborators      map[string][]string
+	teamProjects       map[string]string
+	emailDomains       []string
+	sso                *api.SSOConfiguration
+
+	rateLimitRemaining int
+	rateLimitReset     time.Time
+	usageSummary       string
+}
+
+var _ BugsnagAPI = &fakeAPI{}
+
+func (f *fakeAPI) ListProjects(ctx context.Context) ([]*api.Project, error) {
+	return f.projects, nil
+}
+
+func (f *fakeAPI) IterateProjects(ctx context.Context) api.ProjectsIterator {
+	return func(yield func(*api.Project, error) bool) {
+		for _, project := range f.projects {
+			if !yield(project, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (f *fakeAPI) FindProjectsByName(ctx context.Context, name string) ([]*api.Project, error) {
+	projects := make([]*api.Project, 0)
+	for _, project := range f.projects {
+		if project.Name == name {
+			projects = append(projects, project)
+		}
+	}
+	return projects, nil
+}
+
+func (f *fakeAPI) GetProject(ctx context.Context, projectID string) (*api.Project, error) {
+	for _, project := range f.projects {
+		if project.ID == projectID {
+			return project, nil
+		}
+	}
+	return nil, fmt.Errorf("project %s: %w", projectID, api.ErrNotFound)
+}
+
+func (f *fakeAPI) CreateProject(ctx context.Context, request *api.CreateProjectRequest) (*api.Project, error) {
+	f.created = append(f.created, request)
+
+	project := &api.Project{
+		ID:   strconv.Itoa(len(f.projects) + 1),
+		Name: request.Name,
+		Type: request.Type,
+	}
+	if request.IgnoreOldBrowsers != nil {
+		project.IgnoreOldBrowsers = *request.IgnoreOldBrowsers
+	}
+	f.projects = append(f.projects, project)
+
+	return project, nil
+}
+
+func (f *fakeAPI) UpdateProject(ctx context.Context, projectID string, request *api.UpdateProjectRequest) (*api.Project, error) {
+	project, err := f.GetProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if request.Name != nil {
+		project.Name = *request.Name
+	}
+	if request.IgnoreOldBrowsers != nil {
+		project.IgnoreOldBrowsers = *request.IgnoreOldBrowsers
+	}
+	return project, nil
+}
+
+func (f *fakeAPI) DeleteProject(ctx context.Context, projectID string) error {
+	for i, project := range f.projects {
+		if project.ID == projectID {
+			f.projects = append(f.projects[:i], f.projects[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("project %s: %w", projectID, api.ErrNotFound)
+}
+
+func (f *fakeAPI) RegenerateProjectAPIKey(ctx context.Context, projectID string) (string, error) {
+	project, err := f.GetProject(ctx, projectID)
+	if err != nil {
+		return "", err
+	}
+	f.keys++
+	project.APIKey = fmt.Sprintf("%032d", f.keys)
+	return project.APIKey, nil
+}
+
+func (f *fakeAPI) GetEmailNotificationSettings(ctx context.Context, projectID string) (*api.EmailNotificationSettings, error) {
+	if settings, ok := f.emailNotifications[projectID]; ok {
+		return settings, nil
+	}
+	return &api.EmailNotificationSettings{NewErrors: true, ReopenedErrors: true, ErrorSpikes: true}, nil
+}
+
+func (f *fakeAPI) UpdateEmailNotificationSettings(ctx context.Context, projectID string, settings *api.EmailNotificationSettings) (*api.EmailNotificationSettings, error) {
+	if f.emailNotifications == nil {
+		f.emailNotifications = make(map[string]*api.EmailNotificationSettings)
+	}
+	f.emailNotifications[projectID] = settings
+	return settings, nil
+}
+
+func (f *fakeAPI) GetReopenRules(ctx context.Context, projectID string) (*api.ReopenRules, error) {
+	if rules, ok := f.reopenRules[projectID]; ok {
+		return rules, nil
+	}
+	return &api.ReopenRules{Occurrences: 10, Hours: 24}, nil
+}
+
+func (f *fakeAPI) UpdateReopenRules(ctx context.Context, projectID string, rules *api.ReopenRules) (*api.ReopenRules, error) {
+	if f.reopenRules == nil {
+		f.reopenRules = make(map[string]*api.ReopenRules)
+	}
+	f.reopenRules[projectID] = rules
+	return rules, nil
+}
+
+func (f *fakeAPI) GetSpikeDetectionSettings(ctx context.Context, projectID string) (*api.SpikeDetectionSettings, error) {
+	if settings, ok := f.spikeDetection[projectID]; ok {
+		return settings, nil
+	}
+	return &api.SpikeDetectionSettings{Enabled: true, ThresholdMultiplier: 4, MinimumEvents: 10, WindowMinutes: 60, NotifyIntegrations: true}, nil
+}
+
+func (f *fakeAPI) UpdateSpikeDetectionSettings(ctx context.Context, projectID string, settings *api.SpikeDetectionSettings) (*api.SpikeDetectionSettings, error) {
+	if f.spikeDetection == nil {
+		f.spikeDetection = make(map[string]*api.SpikeDetectionSettings)
+	}
+	f.spikeDetection[projectID] = settings
+	return settings, nil
+}
+
+func (f *fakeAPI) GetStabilityTargets(ctx context.Context, projectID string) (*api.StabilityTargets, error) {
+	if targets, ok := f.stabilityTargets[projectID]; ok {
+		return targets, nil
+	}
+	target, critical := 99.5, 98.0
+	return &api.StabilityTargets{TargetStability: &target, CriticalStability: &critical}, nil
+}
+
+func (f *fakeAPI) UpdateStabilityTargets(ctx context.Context, projectID string, targets *api.StabilityTargets) (*api.StabilityTargets, error) {
+	current, _ := f.GetStabilityTargets(ctx, projectID)
+	updated := *current
+	if targets.TargetStability != nil {
+		updated.TargetStability = targets.TargetStability
+	}
+	if targets.CriticalStability != nil {
+		updated.CriticalStability = targets.CriticalStability
+	}
+
+	if f.stabilityTargets == nil {
+		f.stabilityTargets = make(map[string]*api.StabilityTargets)
+	}
+	f.stabilityTargets[projectID] = &updated
+	return &updated, nil
+}
+
+func (f *fakeAPI) GetProjectStability(ctx context.Context, projectID string) (*api.ProjectStability, error) {
+	return &api.ProjectStability{}, nil
+}
+
+func (f *fakeAPI) GetEventUsage(ctx context.Context, projectID string) (*api.EventUsage, error) {
+	return &api.EventUsage{}, nil
+}
+
+func (f *fakeAPI) ListEventFields(ctx context.Context, projectID string) ([]*api.EventField, error) {
+	return f.eventFields[projectID], nil
+}
+
+func (f *fakeAPI) CreateEventField(ctx context.Context, projectID string, field *api.EventField) (*api.EventField, error) {
+	if f.eventFields == nil {
+		f.eventFields = make(map[string][]*api.EventField)
+	}
+	f.eventFields[projectID] = append(f.eventFields[projectID], field)
+	return field, nil
+}
+
+func (f *fakeAPI) UpdateEventField(ctx context.Context, projectID, displayID string, field *api.EventField) (*api.EventField, error) {
+	for i, existing := range f.eventFields[projectID] {
+		if existing.DisplayID == displayID {
+			f.eventFields[projectID][i] = field
+			return field, nil
+		}
+	}
+	return nil, fmt.Errorf("event field %s: %w", displayID, api.ErrNotFound)
+}
+
+func (f *fakeAPI) DeleteEventField(ctx context.Context, projectID, displayID string) error {
+	for i, existing := range f.eventFields[projectID] {
+		if existing.DisplayID == displayID {
+			f.eventFields[projectID] = append(f.eventFields[projectID][:i], f.eventFields[projectID][i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("event field %s: %w", displayID, api.ErrNotFound)
+}
+
+func (f *fakeAPI) CreateWebhook(ctx context.Context, projectID string, request *api.CreateWebhookRequest) (*api.Webhook, error) {
+	if f.webhooks == nil {
+		f.webhooks = make(map[string]*api.Webhook)
+	}
+	webhook := &api.Webhook{ID: strconv.Itoa(len(f.webhooks) + 1), ProjectID: projectID, URL: request.URL, Triggers: request.Triggers}
+	f.webhooks[webhook.ID] = webhook
+	return webhook, nil
+}
+
+func (f *fakeAPI) GetWebhook(ctx context.Context, projectID, webhookID string) (*api.Webhook, error) {
+	webhook, ok := f.webhooks[webhookID]
+	if !ok || webhook.ProjectID != projectID {
+		return nil, fmt.Errorf("webhook %s: %w", webhookID, api.ErrNotFound)
+	}
+	return webhook, nil
+}
+
+func (f *fakeAPI) UpdateWebhook(ctx context.Context, projectID, webhookID string, request *api.UpdateWebhookRequest) (*api.Webhook, error) {
+	webhook, err := f.GetWebhook(ctx, projectID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if request.URL != nil {
+		webhook.URL = *request.URL
+	}
+	if request.Triggers != nil {
+		webhook.Triggers = *request.Triggers
+	}
+	return webhook, nil
+}
+
+func (f *fakeAPI) DeleteWebhook(ctx context.Context, projectID, webhookID string) error {
+	if _, err := f.GetWebhook(ctx, projectID, webhookID); err != nil {
+		return err
+	}
+	delete(f.webhooks, webhookID)
+	return nil
+}
+
+func (f *fakeAPI) GetGitLabIntegration(ctx context.Context, projectID string) (*api.GitLabIntegration, error) {
+	integration, ok := f.gitlab[projectID]
+	if !ok {
+		return nil, fmt.Errorf("GitLab integration of %s: %w", projectID, api.ErrNotFound)
+	}
+	return integration, nil
+}
+
+func (f *fakeAPI) UpdateGitLabIntegration(ctx context.Context, projectID string, integration *api.GitLabIntegration) (*api.GitLabIntegration, error) {
+	if f.gitlab == nil {
+		f.gitlab = make(map[string]*api.GitLabIntegration)
+	}
+	f.gitlab[projectID] = &api.GitLabIntegration{InstanceURL: integration.InstanceURL, ProjectPath: integration.ProjectPath}
+	return f.gitlab[projectID], nil
+}
+
+func (f *fakeAPI) DeleteGitLabIntegration(ctx context.Context, projectID string) error {
+	if _, err := f.GetGitLabIntegration(ctx, projectID); err != nil {
+		return err
+	}
+	delete(f.gitlab, projectID)
+	return nil
+}
+
+func (f *fakeAPI) InviteCollaborators(ctx context.Context, emails []string, projectIDs []string) error {
+	for _, email := range emails {
+		if err := f.AddCollaboratorProjects(ctx, email, projectIDs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeAPI) AddCollaboratorProjects(ctx context.Context, collaboratorID string, projectIDs []string) error {
+	if f.collaborators == nil {
+		f.collaborators = make(map[string][]string)
+	}
+	f.collaborators[collaboratorID] = append(f.collaborators[collaboratorID], projectIDs...)
+	return nil
+}
+
+func (f *fakeAPI) InviteCollaborator(ctx context.Context, request *api.InviteCollaboratorRequest) (*api.Collaborator, error) {
+	if err := f.AddCollaboratorProjects(ctx, request.Email, request.ProjectIDs); err != nil {
+		return nil, err
+	}
+	return &api.Collaborator{ID: request.Email, Email: request.Email, IsAdmin: request.Admin, ProjectIDs: f.collaborators[request.Email]}, nil
+}
+
+func (f *fakeAPI) ListCollaborators(ctx context.Context) ([]*api.Collaborator, error) {
+	collaborators := make([]*api.Collaborator, 0, len(f.collaborators))
+	for _, collaboratorID := range slices.Sorted(maps.Keys(f.collaborators)) {
+		collaborator, _ := f.GetCollaborator(ctx, collaboratorID)
+		collaborators = append(collaborators, collaborator)
+	}
+	return collaborators, nil
+}
+
+func (f *fakeAPI) GetCollaborator(ctx context.Context, collaboratorID string) (*api.Collaborator, error) {
+	projectIDs, ok := f.collaborators[collaboratorID]
+	if !ok {
+		return nil, fmt.Errorf("collaborator %s: %w", collaboratorID, api.ErrNotFound)
+	}
+	return &api.Collaborator{ID: collaboratorID, Email: collaboratorID, ProjectIDs: projectIDs}, nil
+}
+
+func (f *fakeAPI) UpdateCollaborator(ctx context.Context, collaboratorID string, request *api.UpdateCollaboratorRequest) (*api.Collaborator, error) {
+	if _, ok := f.collaborators[collaboratorID]; !ok {
+		return nil, fmt.Errorf("collaborator %s: %w", collaboratorID, api.ErrNotFound)
+	}
+	if request.ProjectIDs != nil {
+		f.collaborators[collaboratorID] = *request.ProjectIDs
+	}
+	return f.GetCollaborator(ctx, collaboratorID)
+}
+
+func (f *fakeAPI) DeleteCollaborator(ctx context.Context, collaboratorID string) error {
+	if _, ok := f.collaborators[collaboratorID]; !ok {
+		return fmt.Errorf("collaborator %s: %w", collaboratorID, api.ErrNotFound)
+	}
+	delete(f.collaborators, collaboratorID)
+	return nil
+}
+
+func (f *fakeAPI) ResendCollaboratorInvitation(ctx context.Context, collaboratorID string) error {
+	if _, ok := f.collaborators[collaboratorID]; !ok {
+		return fmt.Errorf("collaborator %s: %w", collaboratorID, api.ErrNotFound)
+	}
+	return nil
+}
+
+func (f *fakeAPI) SetTeamProject(ctx context.Context, teamID, projectID, permission string) (*api.TeamProject, error) {
+	if f.teamProjects == nil {
+		f.teamProjects = make(map[string]string)
+	}
+	f.teamProjects[teamID+"/"+projectID] = permission
+	return &api.TeamProject{TeamID: teamID, ProjectID: projectID, Permission: permission}, nil
+}
+
+func (f *fakeAPI) GetTeamProject(ctx context.Context, teamID, projectID string) (*api.TeamProject, error) {
+	permission, ok := f.teamProjects[teamID+"/"+projectID]
+	if !ok {
+		return nil, fmt.Errorf("team %s project %s: %w", teamID, projectID, api.ErrNotFound)
+	}
+	return &api.TeamProject{TeamID: teamID, ProjectID: projectID, Permission: permission}, nil
+}
+
+func (f *fakeAPI) RemoveTeamProject(ctx context.Context, teamID, projectID string) error {
+	if _, ok := f.teamProjects[teamID+"/"+projectID]; !ok {
+		return fmt.Errorf("team %s project %s: %w", teamID, projectID, api.ErrNotFound)
+	}
+	delete(f.teamProjects, teamID+"/"+projectID)
+	return nil
+}
+
+func (f *fakeAPI) ListAllowedEmailDomains(ctx context.Context) ([]string, error) {
+	return f.emailDomains, nil
+}
+
+func (f *fakeAPI) AddAllowedEmailDomain(ctx context.Context, domain string) error {
+	if !slices.Contains(f.emailDomains, domain) {
+		f.emailDomains = append(f.emailDomains, domain)
+	}
+	return nil
+}
+
+func (f *fakeAPI) RemoveAllowedEmailDomain(ctx context.Context, domain string) error {
+	if !slices.Contains(f.emailDomains, domain) {
+		return fmt.Errorf("email domain %s: %w", domain, api.ErrNotFound)
+	}
+	f.emailDomains = slices.DeleteFunc(f.emailDomains, func(d string) bool { return d == domain })
+	return nil
+}
+
+func (f *fakeAPI) GetSSOConfiguration(ctx context.Context) (*api.SSOConfiguration, error) {
+	if f.sso == nil {
+		return nil, fmt.Errorf("SSO configuration: %w", api.ErrNotFound)
+	}
+	return f.sso, nil
+}
+
+func (f *fakeAPI) UpdateSSOConfiguration(ctx context.Context, configuration *api.SSOConfiguration) (*api.SSOConfiguration, error) {
+	f.sso = configuration
+	return configuration, nil
+}
+
+func (f *fakeAPI) DeleteSSOConfiguration(ctx context.Context) error {
+	if f.sso == nil {
+		return fmt.Errorf("SSO configuration: %w", api.ErrNotFound)
+	}
+	f.sso = nil
+	return nil
+}
+
+func (f *fakeAPI) CreateAccessToken(ctx context.Context, ttl time.Duration) (*api.AccessToken, error) {
+	return &api.AccessToken{ID: "t1", Token: "secret", ExpiresAt: time.Now().Add(ttl)}, nil
+}
+
+func (f *fakeAPI) RevokeAccessToken(ctx context.Context, id string) error {
+	return nil
+}
+
+func (f *fakeAPI) RateLimitWarning() (int, time.Time, bool) {
+	return f.rateLimitRemaining, f.rateLimitReset, !f.rateLimitReset.IsZero()
+}
+
+func (f *fakeAPI) UsageSummary() (string, bool) {
+	return f.usageSummary, f.usageSummary != ""
+}
+
+func TestRateLimitDiagnostics(t *testing.T) {
+	c := &Client{BugsnagAPI: &fakeAPI{}}
+	if diags := rateLimitDiagnostics(c); len(diags) != 0 {
+		t.Fatalf("expected no diagnostics, got %v", diags)
+	}
+
+	c = &Client{BugsnagAPI: &fakeAPI{rateLimitRemaining: 2, rateLimitReset: time.Now().Add(time.Minute)}}
+	if diags := rateLimitDiagnostics(c); len(diags) != 1 || diags.HasError() {
+		t.Fatalf("expected a single warning, got %v", diags)
+	}
+
+	c = &Client{BugsnagAPI: &fakeAPI{usageSummary: "Bugsnag API usage: 3 GETs"}}
+	diags := rateLimitDiagnostics(c)
+	if len(diags) != 1 || diags.HasError() || !strings.HasPrefix(diags[0].Detail, "Bugsnag API usage: 3 GETs") {
+		t.Fatalf("expected the usage summary as a warning, got %v", diags)
+	}
+}
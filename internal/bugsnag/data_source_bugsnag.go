@@ -4,18 +4,21 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
 )
 
-func getProjectSchema(nameRequired bool, typeRequired bool, ignore_old_browsers bool) map[string]*schema.Schema {
+func getProjectSchema(nameRequired bool, typeConfigurable bool, ignore_old_browsers bool) map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"name": {
-			Type:     schema.TypeString,
-			Computed: !nameRequired,
-			Required: nameRequired,
+			Type:        schema.TypeString,
+			Computed:    !nameRequired,
+			Required:    nameRequired,
+			Description: "The name of the project.",
 		},
 		"global_grouping": {
 			Type:     schema.TypeList,
@@ -23,6 +26,7 @@ func getProjectSchema(nameRequired bool, typeRequired bool, ignore_old_browsers
 			Elem: &schema.Schema{
 				Type: schema.TypeString,
 			},
+			Description: "The error classes grouped into a single error regardless of where they occur.",
 		},
 		"location_grouping": {
 			Type:     schema.TypeList,
@@ -30,6 +34,7 @@ func getProjectSchema(nameRequired bool, typeRequired bool, ignore_old_browsers
 			Elem: &schema.Schema{
 				Type: schema.TypeString,
 			},
+			Description: "The error classes grouped by where they occur, regardless of their message.",
 		},
 		"discarded_app_versions": {
 			Type:     schema.TypeList,
@@ -37,6 +42,7 @@ func getProjectSchema(nameRequired bool, typeRequired bool, ignore_old_browsers
 			Elem: &schema.Schema{
 				Type: schema.TypeString,
 			},
+			Description: "The app versions whose events are discarded.",
 		},
 		"discarded_errors": {
 			Type:     schema.TypeList,
@@ -44,6 +50,7 @@ func getProjectSchema(nameRequired bool, typeRequired bool, ignore_old_browsers
 			Elem: &schema.Schema{
 				Type: schema.TypeString,
 			},
+			Description: "The error classes whose events are discarded.",
 		},
 		"url_whitelist": {
 			Type:     schema.TypeList,
@@ -51,6 +58,7 @@ func getProjectSchema(nameRequired bool, typeRequired bool, ignore_old_browsers
 			Elem: &schema.Schema{
 				Type: schema.TypeString,
 			},
+			Description: "The URLs of the pages errors are accepted from, for browser projects. Errors from every URL are accepted when empty.",
 		},
 		"ignore_old_browsers": getIgnoreOldBrowsers(ignore_old_browsers),
 		"ignored_browser_versions": {
@@ -59,35 +67,49 @@ func getProjectSchema(nameRequired bool, typeRequired bool, ignore_old_browsers
 			Elem: &schema.Schema{
 				Type: schema.TypeString,
 			},
+			Description: "The browsers whose old versions are ignored, mapped to the oldest version accepted, when `ignore_old_browsers` is set.",
 		},
 		"resolve_on_deploy": {
-			Type:     schema.TypeBool,
-			Computed: true,
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether errors are resolved when a new version of the project is deployed.",
+		},
+		"collaborators_can_modify_settings": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether collaborators who aren't organization admins may change the settings of the project.",
 		},
 		"id": {
-			Type:     schema.TypeString,
-			Computed: true,
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the project.",
 		},
 		"organization_id": {
-			Type:     schema.TypeString,
-			Computed: true,
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the organization the project belongs to.",
 		},
 		"type": {
-			Type:     schema.TypeString,
-			Computed: !typeRequired,
-			Required: typeRequired,
+			Type:        schema.TypeString,
+			Computed:    true,
+			Optional:    typeConfigurable,
+			Description: "The type of the project, i.e. the platform or framework it uses, such as `rails` or `android`.",
 		},
 		"slug": {
-			Type:     schema.TypeString,
-			Computed: true,
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The unique identifier of the project in dashboard URLs.",
 		},
 		"api_key": {
-			Type:     schema.TypeString,
-			Computed: true,
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "The notifier API key of the project, with which apps send it their errors.",
 		},
 		"is_full_view": {
-			Type:     schema.TypeBool,
-			Computed: true,
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the API token can see every error of the project.",
 		},
 		"release_stages": {
 			Type:     schema.TypeList,
@@ -95,57 +117,250 @@ func getProjectSchema(nameRequired bool, typeRequired bool, ignore_old_browsers
 			Elem: &schema.Schema{
 				Type: schema.TypeString,
 			},
+			Description: "The release stages, such as `production`, the project has received errors from.",
 		},
 		"language": {
-			Type:     schema.TypeString,
-			Computed: true,
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The programming language of the project.",
+		},
+		"framework": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The framework of the project, such as `react`, detected from the events it receives. Empty until then.",
+		},
+		"platform": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The platform of the project, such as `browser`, detected from the events it receives. Empty until then.",
+		},
+		"notifier_language_version": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The version of the language the project's notifier runs on, such as `18.2.0`, detected from the events it receives. Empty until then.",
 		},
 		"created_at": {
-			Type:     schema.TypeString,
-			Computed: true,
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "When the project was created, in RFC 3339 format.",
 		},
 		"updated_at": {
-			Type:     schema.TypeString,
-			Computed: true,
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "When the project was last updated, in RFC 3339 format.",
 		},
 		"url": {
-			Type:     schema.TypeString,
-			Computed: true,
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The URL of the project in the Bugsnag API.",
 		},
 		"html_url": {
-			Type:     schema.TypeString,
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The URL of the project in the Bugsnag dashboard.",
+		},
+		"dashboard_urls": {
+			Type:     schema.TypeList,
 			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"errors": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The URL of the project's errors inbox in the Bugsnag dashboard.",
+					},
+					"timeline": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The URL of the project's timeline in the Bugsnag dashboard.",
+					},
+					"releases": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The URL of the project's releases in the Bugsnag dashboard.",
+					},
+					"open_errors": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The URL of the project's errors inbox in the Bugsnag dashboard, filtered to its open errors.",
+					},
+					"recent_errors": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The URL of the project's errors inbox in the Bugsnag dashboard, filtered to the errors seen in the last 24 hours.",
+					},
+				},
+			},
+			Description: "Links to the pages of the project in the Bugsnag dashboard, such as for runbooks and service catalogs.",
 		},
 		"errors_url": {
-			Type:     schema.TypeString,
-			Computed: true,
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The URL of the project's errors in the Bugsnag API.",
 		},
 		"events_url": {
-			Type:     schema.TypeString,
-			Computed: true,
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The URL of the project's events in the Bugsnag API.",
+		},
+		"open_errors_url": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The URL of the project's open errors in the Bugsnag API.",
 		},
+		"recent_events_url": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The URL of the project's events of the last 24 hours in the Bugsnag API.",
+		},
+	}
+}
+
+// projectTelemetrySchema holds the counters of a project, which change with
+// every error Bugsnag receives. Only the data sources expose them, so that
+// they never churn the state of bugsnag_project.
+func projectTelemetrySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
 		"open_error_count": {
-			Type:     schema.TypeInt,
-			Computed: true,
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The number of open errors of the project.",
 		},
 		"for_review_error_count": {
-			Type:     schema.TypeInt,
-			Computed: true,
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The number of errors of the project marked for review.",
 		},
 		"collaborators_count": {
-			Type:     schema.TypeInt,
-			Computed: true,
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The number of collaborators with access to the project.",
 		},
 		"custom_event_fields_used": {
-			Type:     schema.TypeInt,
-			Computed: true,
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The number of custom event fields the project uses.",
+		},
+	}
+}
+
+// fetchStatisticsSchema is the fetch_statistics argument, with which the
+// project counters and stability, which change all the time and some of
+// which take requests of their own, are left out, e.g. to speed up
+// refreshes in CI.
+func fetchStatisticsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: "Whether to read the counters and stability of the project, which change all the time. Set to `false` to skip them and speed up refreshes; they are then left unset.",
+	}
+}
+
+// flattenProject returns the value of each attribute of getProjectSchema for
+// a project.
+func flattenProject(project *api.Project) map[string]interface{} {
+	ignoredBrowserVersions := make(map[string]interface{}, len(project.IgnoredBrowserVersions))
+	for browser, version := range project.IgnoredBrowserVersions {
+		ignoredBrowserVersions[browser] = fmt.Sprint(version)
+	}
+
+	return map[string]interface{}{
+		"name":                     project.Name,
+		"global_grouping":          project.GlobalGrouping,
+		"location_grouping":        project.LocationGrouping,
+		"discarded_app_versions":   project.DiscardedAppVersions,
+		"discarded_errors":         project.DiscardedErrors,
+		"url_whitelist":            project.URLWhitelist,
+		"ignore_old_browsers":      project.IgnoreOldBrowsers,
+		"ignored_browser_versions": ignoredBrowserVersions,
+		"resolve_on_deploy":        project.ResolveOnDeploy,
+		"id":                       project.ID,
+		"organization_id":          project.OrganizationID,
+		"type":                     project.Type,
+		"slug":                     project.Slug,
+		"api_key":                  project.APIKey,
+		"is_full_view":             project.IsFullView,
+		"release_stages":           project.ReleaseStages,
+		"language":                 project.Language,
+		"created_at":               project.CreatedAt,
+		"updated_at":               project.UpdatedAt,
+		"url":                      project.URL,
+		"html_url":                 project.HTMLURL,
+		"dashboard_urls":           flattenDashboardURLs(project.HTMLURL),
+		"errors_url":               project.ErrorsURL,
+		"events_url":               project.EventsURL,
+		"open_errors_url":          filteredURL(project.ErrorsURL, openErrorsFilter),
+		"recent_events_url":        filteredURL(project.EventsURL, lastDayFilter),
+		// detected from the events the project receives
+		"framework":                 project.Framework,
+		"platform":                  project.Platform,
+		"notifier_language_version": project.NotifierLanguageVersion,
+		// permissions
+		"collaborators_can_modify_settings": project.CollaboratorsCanModifySettings,
+	}
+}
+
+// flattenDashboardURLs returns the dashboard_urls of a project from its
+// html_url, the URL of its page in the dashboard, which the others are
+// under.
+func flattenDashboardURLs(htmlURL string) []interface{} {
+	if htmlURL == "" {
+		return []interface{}{}
+	}
+
+	htmlURL = strings.TrimSuffix(htmlURL, "/")
+	return []interface{}{
+		map[string]interface{}{
+			"errors":   htmlURL + "/errors",
+			"timeline": htmlURL + "/timeline",
+			"releases": htmlURL + "/releases",
+
+			"open_errors":   filteredURL(htmlURL+"/errors", dashboardOpenErrorsFilter),
+			"recent_errors": filteredURL(htmlURL+"/errors", dashboardLastDayFilter),
 		},
 	}
 }
 
+// The filters of the filtered URLs of a project: the API takes filters as
+// lists of comparisons, the dashboard as plain values.
+const (
+	openErrorsFilter = "filters[error.status][][type]=eq&filters[error.status][][value]=open"
+	lastDayFilter    = "filters[event.since][][type]=eq&filters[event.since][][value]=1d"
+
+	dashboardOpenErrorsFilter = "filters[error.status]=open"
+	dashboardLastDayFilter    = "filters[event.since]=1d"
+)
+
+// filteredURL returns u with the given filters added to its query, or an
+// empty string if u is empty.
+func filteredURL(u string, filters ...string) string {
+	if u == "" {
+		return ""
+	}
+
+	separator := "?"
+	if strings.Contains(u, "?") {
+		separator = "&"
+	}
+	return u + separator + strings.Join(filters, "&")
+}
+
+// flattenProjectWithTelemetry returns the value of each attribute of
+// getProjectSchema and projectTelemetrySchema for a project.
+func flattenProjectWithTelemetry(project *api.Project) map[string]interface{} {
+	flattened := flattenProject(project)
+	flattened["open_error_count"] = project.OpenErrorCount
+	flattened["for_review_error_count"] = project.ForReviewErrorCount
+	flattened["collaborators_count"] = project.CollaboratorsCount
+	flattened["custom_event_fields_used"] = project.CustomEventFieldsUsed
+	return flattened
+}
+
 func getIgnoreOldBrowsers(ignoreOldBrowsers bool) *schema.Schema {
 	sch := schema.Schema{
-		Type: schema.TypeBool,
+		Type:        schema.TypeBool,
+		Description: "Whether errors from the old browser versions listed in `ignored_browser_versions` are ignored.",
 	}
 
 	if ignoreOldBrowsers {
@@ -157,16 +372,29 @@ func getIgnoreOldBrowsers(ignoreOldBrowsers bool) *schema.Schema {
 	return &sch
 }
 
+// dataSourceProjectSchema returns the schema of a project read by the data
+// sources, which includes its telemetry.
+func dataSourceProjectSchema(nameRequired bool) map[string]*schema.Schema {
+	s := getProjectSchema(nameRequired, false, true)
+	for k, v := range projectTelemetrySchema() {
+		s[k] = v
+	}
+	return s
+}
+
 func dataSourceProjects() *schema.Resource {
 	return &schema.Resource{
+		Description: "Lists every project of the organization.",
 		ReadContext: dataSourceProjectsRead,
 		Schema: map[string]*schema.Schema{
+			"fetch_statistics": fetchStatisticsSchema(),
 			"projects": {
 				Type:     schema.TypeList,
 				Computed: true,
 				Elem: &schema.Resource{
-					Schema: getProjectSchema(false, false, true),
+					Schema: dataSourceProjectSchema(false),
 				},
+				Description: "Every project of the organization.",
 			},
 		},
 	}
@@ -177,26 +405,38 @@ func dataSourceProjectsRead(ctx context.Context, d *schema.ResourceData, m inter
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
-	projects, diags := client.listProjects()
-	if len(diags) > 0 {
-		return diags
+	flatten := flattenProjectWithTelemetry
+	if !d.Get("fetch_statistics").(bool) {
+		flatten = flattenProject
 	}
 
-	if err := d.Set("projects", projects); err != nil {
+	flattened := make([]interface{}, 0)
+	for project, err := range client.IterateProjects(ctx) {
+		if err != nil {
+			return apiErrorDiagnostics("Unable to list Bugsnag projects", err)
+		}
+		flattened = append(flattened, flatten(project))
+	}
+
+	if err := d.Set("projects", flattened); err != nil {
 		return diag.FromErr(err)
 	}
 
 	// always run
 	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
 
-	return diags
+	return append(diags, rateLimitDiagnostics(client)...)
 }
 
 // single project
 func dataSourceProject() *schema.Resource {
+	s := dataSourceProjectSchema(true)
+	s["fetch_statistics"] = fetchStatisticsSchema()
+
 	return &schema.Resource{
+		Description: "Reads a project of the organization by its name.",
 		ReadContext: dataSourceProjectRead,
-		Schema:      getProjectSchema(true, false, true),
+		Schema:      s,
 	}
 }
 
@@ -206,25 +446,27 @@ func dataSourceProjectRead(ctx context.Context, d *schema.ResourceData, m interf
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
-	projects, diags := client.listProjects()
-	if len(diags) > 0 {
-		return diags
+	flatten := flattenProjectWithTelemetry
+	if !d.Get("fetch_statistics").(bool) {
+		flatten = flattenProject
 	}
 
 	projectName := d.Get("name").(string)
-	for _, project := range projects {
-		if project["name"] == projectName {
-			for v := range getProjectSchema(true, false, true) {
-				if err := d.Set(v, project[v]); err != nil {
-					return diag.FromErr(err)
-				}
+	projects, err := client.FindProjectsByName(ctx, projectName)
+	if err != nil {
+		return apiErrorDiagnostics("Unable to list Bugsnag projects", err)
+	}
+	if len(projects) > 0 {
+		for k, v := range flatten(projects[0]) {
+			if err := d.Set(k, v); err != nil {
+				return diag.FromErr(err)
 			}
+		}
 
-			// always run
-			d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+		// always run
+		d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
 
-			return diags
-		}
+		return append(diags, rateLimitDiagnostics(client)...)
 	}
 
 	d.SetId("")
@@ -0,0 +1,64 @@
+package bugsnag_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-provider-bugsnag/pkg/acctest"
+)
+
+func TestAccDataSourceProject(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		CheckDestroy:             acctest.CheckProjectDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceProject,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.bugsnag_project.test", "api_key", "bugsnag_project.test", "api_key"),
+					resource.TestCheckResourceAttr("data.bugsnag_project.test", "type", "js"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceProjects(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		CheckDestroy:             acctest.CheckProjectDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceProjects,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.bugsnag_projects.all", "projects.#", regexp.MustCompile("^[1-9]")),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceProject = `
+resource "bugsnag_project" "test" {
+  name = "tf-acc-test-data-source"
+  type = "js"
+}
+
+data "bugsnag_project" "test" {
+  name = bugsnag_project.test.name
+}
+`
+
+const testAccDataSourceProjects = `
+resource "bugsnag_project" "test" {
+  name = "tf-acc-test-data-sources"
+  type = "go"
+}
+
+data "bugsnag_projects" "all" {
+  depends_on = [bugsnag_project.test]
+}
+`
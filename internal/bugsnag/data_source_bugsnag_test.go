@@ -1,32 +1,124 @@
 package bugsnag
 
 import (
-	"regexp"
+	"context"
+	"fmt"
+	"strings"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-bugsnag/internal/bugsnagtest"
 )
 
-func TestAccDataSourceBugsnag(t *testing.T) {
-	t.Skip("data source not yet implemented, remove this once you add your own code")
-
-	resource.UnitTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
-		Steps: []resource.TestStep{
-			{
-				Config: testAccDataSourceBugsnag,
-				Check: resource.ComposeTestCheckFunc(
-					resource.TestMatchResourceAttr(
-						"data.bugsnag_data_source.foo", "sample_attribute", regexp.MustCompile("^ba")),
-				),
-			},
-		},
+func TestDataSourceProjectsRead(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	server.AddProject(map[string]interface{}{"name": "api", "type": "go", "open_error_count": 3})
+	server.AddProject(map[string]interface{}{"name": "web", "type": "js"})
+
+	d := schema.TestResourceDataRaw(t, dataSourceProjects().Schema, map[string]interface{}{})
+
+	if diags := dataSourceProjectsRead(context.Background(), d, newTestClient(server)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	projects := d.Get("projects").([]interface{})
+	if len(projects) != 2 {
+		t.Fatalf("expected 2 projects, got %d", len(projects))
+	}
+	if count := projects[0].(map[string]interface{})["open_error_count"]; count != 3 {
+		t.Fatalf("expected the counters to be read, got %v", projects[0])
+	}
+
+	d = schema.TestResourceDataRaw(t, dataSourceProjects().Schema, map[string]interface{}{
+		"fetch_statistics": false,
+	})
+
+	requests := len(server.Requests())
+	if diags := dataSourceProjectsRead(context.Background(), d, newTestClient(server)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	// the SDK reads unset attributes back as their zero value
+	if count := d.Get("projects").([]interface{})[0].(map[string]interface{})["open_error_count"]; count != 0 {
+		t.Fatalf("expected the counters to be left out without fetch_statistics, got %v", count)
+	}
+	for _, request := range server.Requests()[requests:] {
+		if !strings.HasSuffix(request, "/projects") {
+			t.Fatalf("expected only the projects to be listed without fetch_statistics, got %s", request)
+		}
+	}
+}
+
+func TestDataSourceProjectRead(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	project := server.AddProject(map[string]interface{}{
+		"name":                      "api",
+		"type":                      "go",
+		"framework":                 "gin",
+		"platform":                  "server",
+		"notifier_language_version": "1.22.1",
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceProject().Schema, map[string]interface{}{
+		"name": "api",
+	})
+
+	if diags := dataSourceProjectRead(context.Background(), d, newTestClient(server)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Get("api_key").(string) != project["api_key"] {
+		t.Fatalf("expected the project to be read, got api_key %q", d.Get("api_key"))
+	}
+	urls := d.Get("dashboard_urls").([]interface{})
+	if len(urls) != 1 || urls[0].(map[string]interface{})["errors"] != project["html_url"].(string)+"/errors" {
+		t.Fatalf("expected the dashboard URLs to be built from html_url %v, got %v", project["html_url"], urls)
+	}
+	if open := urls[0].(map[string]interface{})["open_errors"]; open != project["html_url"].(string)+"/errors?filters[error.status]=open" {
+		t.Fatalf("expected the open errors URL to filter the errors inbox, got %v", open)
+	}
+	if recent := d.Get("recent_events_url").(string); recent != project["events_url"].(string)+"?filters[event.since][][type]=eq&filters[event.since][][value]=1d" {
+		t.Fatalf("expected the recent events URL to filter events_url, got %v", recent)
+	}
+	if d.Get("framework") != "gin" || d.Get("platform") != "server" || d.Get("notifier_language_version") != "1.22.1" {
+		t.Fatalf("expected the detected metadata to be read, got %q, %q and %q", d.Get("framework"), d.Get("platform"), d.Get("notifier_language_version"))
+	}
+
+	d = schema.TestResourceDataRaw(t, dataSourceProject().Schema, map[string]interface{}{
+		"name": "missing",
 	})
+
+	if diags := dataSourceProjectRead(context.Background(), d, newTestClient(server)); !diags.HasError() {
+		t.Fatalf("expected an error for a missing project")
+	}
 }
 
-const testAccDataSourceBugsnag = `
-data "bugsnag_data_source" "foo" {
-  sample_attribute = "bar"
+func TestDataSourceProjectReadFiltersByName(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	for i := 0; i < 150; i++ {
+		server.AddProject(map[string]interface{}{"name": fmt.Sprintf("worker %d", i)})
+	}
+	server.AddProject(map[string]interface{}{"name": "api gateway"})
+	project := server.AddProject(map[string]interface{}{"name": "api"})
+
+	d := schema.TestResourceDataRaw(t, dataSourceProject().Schema, map[string]interface{}{
+		"name": "api",
+	})
+
+	requests := len(server.Requests())
+	if diags := dataSourceProjectRead(context.Background(), d, newTestClient(server)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Get("api_key").(string) != project["api_key"] {
+		t.Fatalf("expected the project with the exact name to be read, got api_key %q", d.Get("api_key"))
+	}
+	var listed int
+	for _, request := range server.Requests()[requests:] {
+		if strings.HasSuffix(request, "/projects") {
+			listed++
+		}
+	}
+	if listed != 1 {
+		t.Fatalf("expected the projects to be filtered by name with a single request, got %d", listed)
+	}
 }
-`
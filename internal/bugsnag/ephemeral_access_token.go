@@ -0,0 +1,140 @@
+package bugsnag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
+)
+
+// defaultAccessTokenTTL is how long access tokens stay valid when ttl is not
+// configured.
+const defaultAccessTokenTTL = time.Hour
+
+// accessTokenIDKey is the private data key holding the ID of the issued
+// token, so that Close can revoke it.
+const accessTokenIDKey = "access_token_id"
+
+var (
+	_ ephemeral.EphemeralResourceWithConfigure = &accessTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose     = &accessTokenEphemeralResource{}
+)
+
+// accessTokenEphemeralResource issues a short-lived data access token. The
+// token is only ever held in memory by Terraform and is revoked once the
+// run no longer needs it.
+type accessTokenEphemeralResource struct {
+	client *Client
+}
+
+type accessTokenModel struct {
+	TTL       types.String `tfsdk:"ttl"`
+	Token     types.String `tfsdk:"token"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+}
+
+func newAccessTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &accessTokenEphemeralResource{}
+}
+
+func (r *accessTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_token"
+}
+
+func (r *accessTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Exchanges the provider credentials for a short-lived Bugsnag data access token. " +
+			"The token is never written to the plan or state, and is revoked once Terraform no longer needs it.",
+		Attributes: map[string]schema.Attribute{
+			"ttl": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: fmt.Sprintf("How long the token stays valid, as a Go duration string. Defaults to `%s`.", defaultAccessTokenTTL),
+			},
+			"token": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The access token.",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the token expires, in RFC 3339 format.",
+			},
+		},
+	}
+}
+
+func (r *accessTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected provider data",
+			fmt.Sprintf("Expected *Client, got %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *accessTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data accessTokenModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ttl := defaultAccessTokenTTL
+	if !data.TTL.IsNull() {
+		var err error
+		ttl, err = time.ParseDuration(data.TTL.ValueString())
+		if err != nil || ttl <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ttl"),
+				"Invalid ttl",
+				fmt.Sprintf("ttl must be a positive duration such as \"15m\" or \"1h\", got %q.", data.TTL.ValueString()),
+			)
+			return
+		}
+	}
+
+	token, err := r.client.CreateAccessToken(ctx, ttl)
+	if err != nil {
+		resp.Diagnostics.Append(frameworkDiagnostics(apiErrorDiagnostics("Unable to create a Bugsnag access token", err))...)
+		return
+	}
+
+	data.Token = types.StringValue(token.Token)
+	data.ExpiresAt = types.StringValue(token.ExpiresAt.Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+	if token.ID != "" {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, accessTokenIDKey, []byte(token.ID))...)
+	}
+}
+
+func (r *accessTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	id, diags := req.Private.GetKey(ctx, accessTokenIDKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || len(id) == 0 {
+		return
+	}
+
+	// tokens that no longer exist don't need revoking
+	if err := r.client.RevokeAccessToken(ctx, string(id)); err != nil && !errors.Is(err, api.ErrNotFound) {
+		resp.Diagnostics.AddWarning(
+			"Unable to revoke the Bugsnag access token",
+			fmt.Sprintf("The short-lived access token could not be revoked and stays valid until it expires: %s", err),
+		)
+	}
+}
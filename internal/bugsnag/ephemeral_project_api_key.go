@@ -0,0 +1,97 @@
+package bugsnag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
+)
+
+var _ ephemeral.EphemeralResourceWithConfigure = &projectAPIKeyEphemeralResource{}
+
+// projectAPIKeyEphemeralResource reads the notifier API key of a project for
+// the duration of a run, for configurations that must not keep the key in
+// their state, unlike the api_key of bugsnag_project and the data sources.
+type projectAPIKeyEphemeralResource struct {
+	client *Client
+}
+
+type projectAPIKeyModel struct {
+	ProjectID types.String `tfsdk:"project_id"`
+	APIKey    types.String `tfsdk:"api_key"`
+}
+
+func newProjectAPIKeyEphemeralResource() ephemeral.EphemeralResource {
+	return &projectAPIKeyEphemeralResource{}
+}
+
+func (r *projectAPIKeyEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_api_key"
+}
+
+func (r *projectAPIKeyEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the notifier API key of a Bugsnag project at apply time, to pass to the write-only arguments of other providers. " +
+			"The key is never written to the plan or state, unlike the `api_key` of the `bugsnag_project` resource and data source.",
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the project.",
+			},
+			"api_key": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The notifier API key of the project.",
+			},
+		},
+	}
+}
+
+func (r *projectAPIKeyEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected provider data",
+			fmt.Sprintf("Expected *Client, got %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *projectAPIKeyEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data projectAPIKeyModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	project, err := r.client.GetProject(ctx, data.ProjectID.ValueString())
+	if errors.Is(err, api.ErrNotFound) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("project_id"),
+			"Project not found",
+			fmt.Sprintf("No Bugsnag project has the ID %q.", data.ProjectID.ValueString()),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.Append(frameworkDiagnostics(apiErrorDiagnostics("Unable to read Bugsnag project", err))...)
+		return
+	}
+
+	data.APIKey = types.StringValue(project.APIKey)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,54 @@
+package bugsnag
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &isValidAPIKeyFunction{}
+
+// apiKeyPattern matches Bugsnag notifier API keys, which are 32 hex characters.
+var apiKeyPattern = regexp.MustCompile(`^[0-9a-fA-F]{32}$`)
+
+// isValidAPIKeyFunction implements provider::bugsnag::is_valid_api_key.
+type isValidAPIKeyFunction struct{}
+
+func newIsValidAPIKeyFunction() function.Function {
+	return &isValidAPIKeyFunction{}
+}
+
+func (f *isValidAPIKeyFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "is_valid_api_key"
+}
+
+func (f *isValidAPIKeyFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Checks whether a string is a well-formed Bugsnag notifier API key",
+		MarkdownDescription: "Returns `true` when `key` is 32 hexadecimal characters, the format of a Bugsnag " +
+			"notifier API key. Only the format is checked; the key is not looked up in Bugsnag.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "key",
+				MarkdownDescription: "The notifier API key to check.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *isValidAPIKeyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var key string
+
+	resp.Error = req.Arguments.Get(ctx, &key)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, isValidAPIKey(key))
+}
+
+func isValidAPIKey(key string) bool {
+	return apiKeyPattern.MatchString(key)
+}
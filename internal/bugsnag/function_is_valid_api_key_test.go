@@ -0,0 +1,21 @@
+package bugsnag
+
+import "testing"
+
+func TestIsValidAPIKey(t *testing.T) {
+	cases := map[string]bool{
+		"0123456789abcdef0123456789abcdef":  true,
+		"0123456789ABCDEF0123456789ABCDEF":  true,
+		"0123456789abcdef0123456789abcde":   false,
+		"0123456789abcdef0123456789abcdef0": false,
+		"0123456789abcdef0123456789abcdeg":  false,
+		" 0123456789abcdef0123456789abcdef": false,
+		"":                                  false,
+	}
+
+	for key, want := range cases {
+		if got := isValidAPIKey(key); got != want {
+			t.Errorf("isValidAPIKey(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
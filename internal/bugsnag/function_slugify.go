@@ -0,0 +1,78 @@
+package bugsnag
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"golang.org/x/text/unicode/norm"
+)
+
+var _ function.Function = &slugifyFunction{}
+
+// slugifyFunction implements provider::bugsnag::slugify.
+type slugifyFunction struct{}
+
+func newSlugifyFunction() function.Function {
+	return &slugifyFunction{}
+}
+
+func (f *slugifyFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "slugify"
+}
+
+func (f *slugifyFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Returns the slug Bugsnag generates for a project name",
+		MarkdownDescription: "Reproduces Bugsnag's slug generation, so `slug`-based URLs can be predicted " +
+			"before the project exists: the name is lowercased, accented letters lose their accents, and every " +
+			"run of characters other than ASCII letters, digits and `_`, including `-` itself, becomes a single `-`. " +
+			"Runs at the start or the end of the name are dropped, e.g. `- Checkout -- API -` becomes `checkout-api`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "name",
+				MarkdownDescription: "The project name.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *slugifyFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+
+	resp.Error = req.Arguments.Get(ctx, &name)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, slugify(name))
+}
+
+// slugify turns a project name into its slug the way Bugsnag does, e.g.
+// "My App (Production)" becomes "my-app-production". A "-" is a separator
+// like any other, so "a - b" becomes "a-b". Accented letters are
+// transliterated as Bugsnag does, by decomposing them and dropping their
+// combining marks, e.g. "Näme" becomes "name".
+func slugify(name string) string {
+	var b strings.Builder
+
+	separate := false
+	for _, r := range norm.NFKD.String(strings.ToLower(name)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// the accent of the letter before
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_':
+			if separate && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			separate = false
+			b.WriteRune(r)
+		default:
+			separate = true
+		}
+	}
+
+	return b.String()
+}
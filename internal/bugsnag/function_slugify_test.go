@@ -0,0 +1,23 @@
+package bugsnag
+
+import "testing"
+
+func TestSlugify(t *testing.T) {
+	cases := map[string]string{
+		"api":                  "api",
+		"My App (Production)":  "my-app-production",
+		"  leading/trailing  ": "leading-trailing",
+		"checkout-api":         "checkout-api",
+		"payments--service":    "payments-service",
+		"- Checkout -- API -":  "checkout-api",
+		"snake_case_name":      "snake_case_name",
+		"Ünïcode Näme":         "unicode-name",
+		"Crème Brûlée":         "creme-brulee",
+	}
+
+	for name, want := range cases {
+		if got := slugify(name); got != want {
+			t.Errorf("slugify(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
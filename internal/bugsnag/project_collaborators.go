@@ -0,0 +1,64 @@
+package bugsnag
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// collaboratorsSchema is the collaborators argument of bugsnag_project: the
+// emails or collaborator IDs of people given access to the project. It is
+// not authoritative: access granted otherwise is left alone, and removing
+// someone from the list doesn't revoke their access.
+func collaboratorsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		Description: "The emails of people to invite to the project, or the IDs of collaborators of the organization to give access to it. Access granted otherwise is left alone, and removing someone from the list doesn't revoke their access.",
+	}
+}
+
+// updateCollaborators gives access to the project to the collaborators
+// added to the collaborators argument, all of them when the project is
+// created.
+func updateCollaborators(ctx context.Context, d *schema.ResourceData, c *Client) diag.Diagnostics {
+	old, new := d.GetChange("collaborators")
+
+	granted := make(map[string]bool)
+	if !d.IsNewResource() {
+		for _, collaborator := range expandStringList(old.([]interface{})) {
+			granted[collaborator] = true
+		}
+	}
+
+	var emails []string
+	for _, collaborator := range expandStringList(new.([]interface{})) {
+		if granted[collaborator] {
+			continue
+		}
+
+		// anything but an email is a collaborator ID
+		if strings.Contains(collaborator, "@") {
+			emails = append(emails, collaborator)
+			continue
+		}
+		if err := c.AddCollaboratorProjects(ctx, collaborator, []string{d.Id()}); err != nil {
+			return apiErrorDiagnostics(fmt.Sprintf("Unable to give the Bugsnag collaborator %s access to the project", collaborator), err)
+		}
+	}
+
+	if len(emails) > 0 {
+		if err := c.InviteCollaborators(ctx, emails, []string{d.Id()}); err != nil {
+			return apiErrorDiagnostics("Unable to give the Bugsnag collaborators access to the project", err)
+		}
+	}
+	return nil
+}
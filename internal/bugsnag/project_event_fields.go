@@ -0,0 +1,168 @@
+package bugsnag
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
+)
+
+// customEventFieldSchema is the custom_event_field blocks of
+// bugsnag_project, each declaring a searchable event field read from the
+// event metadata. Once any is set, the project's custom fields are exactly
+// those declared, and removing every block deletes them; a project that
+// never declared any keeps the fields it has. The blocks aren't Computed, so
+// that removing the last one is planned.
+func customEventFieldSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"display_id": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					Description:  "The identifier of the field in searches and in the API, such as `tenant_id`.",
+				},
+				"path": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					Description:  "The path of the field in the event metadata, such as `metaData.tenant.id`.",
+				},
+				"name": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					Description:  "The name of the field in the dashboard.",
+				},
+				"pivot": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Whether the field is offered as a pivot on the errors of the project.",
+				},
+			},
+		},
+		Description: "The custom event fields of the project, by which its events can be filtered. Once any is declared, the project's custom fields are exactly those declared, and removing every block deletes them. A project that never declared any keeps the fields it has, which aren't imported.",
+	}
+}
+
+func expandCustomEventFields(blocks []interface{}) []*api.EventField {
+	fields := make([]*api.EventField, 0, len(blocks))
+	for _, block := range blocks {
+		block, ok := block.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		field := &api.EventField{Custom: true}
+		field.DisplayID, _ = block["display_id"].(string)
+		field.Path, _ = block["path"].(string)
+		field.FilterOptions.Name, _ = block["name"].(string)
+		if pivot, _ := block["pivot"].(bool); pivot {
+			field.PivotOptions = &api.EventFieldPivotOptions{Name: field.FilterOptions.Name}
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+func flattenCustomEventFields(fields []*api.EventField) []interface{} {
+	flattened := make([]interface{}, 0, len(fields))
+	for _, field := range fields {
+		if !field.Custom {
+			continue
+		}
+		flattened = append(flattened, map[string]interface{}{
+			"display_id": field.DisplayID,
+			"path":       field.Path,
+			"name":       field.FilterOptions.Name,
+			"pivot":      field.PivotOptions != nil,
+		})
+	}
+	return flattened
+}
+
+// updateCustomEventFields makes the custom event fields of a project those
+// of its custom_event_field blocks, when they changed: fields not declared
+// are deleted, even the last ones, new ones are created, and changed ones
+// are updated.
+func updateCustomEventFields(ctx context.Context, d *schema.ResourceData, c *Client) diag.Diagnostics {
+	if !d.HasChange("custom_event_field") {
+		return nil
+	}
+	declared := expandCustomEventFields(d.Get("custom_event_field").([]interface{}))
+
+	existing, err := c.ListEventFields(ctx, d.Id())
+	if err != nil {
+		return apiErrorDiagnostics("Unable to list Bugsnag project event fields", err)
+	}
+
+	current := make(map[string]*api.EventField)
+	for _, field := range existing {
+		if field.Custom {
+			current[field.DisplayID] = field
+		}
+	}
+
+	wanted := make(map[string]bool)
+	for _, field := range declared {
+		if wanted[field.DisplayID] {
+			return diag.Errorf("the custom event field %s is declared more than once", field.DisplayID)
+		}
+		wanted[field.DisplayID] = true
+	}
+
+	for displayID := range current {
+		if wanted[displayID] {
+			continue
+		}
+		if err := c.DeleteEventField(ctx, d.Id(), displayID); err != nil {
+			return apiErrorDiagnostics(fmt.Sprintf("Unable to delete the Bugsnag event field %s", displayID), err)
+		}
+	}
+
+	for _, field := range declared {
+		old, ok := current[field.DisplayID]
+		switch {
+		case !ok:
+			if _, err := c.CreateEventField(ctx, d.Id(), field); err != nil {
+				return apiErrorDiagnostics(fmt.Sprintf("Unable to create the Bugsnag event field %s", field.DisplayID), err)
+			}
+		case !eventFieldsEqual(old, field):
+			if _, err := c.UpdateEventField(ctx, d.Id(), field.DisplayID, field); err != nil {
+				return apiErrorDiagnostics(fmt.Sprintf("Unable to update the Bugsnag event field %s", field.DisplayID), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func eventFieldsEqual(a, b *api.EventField) bool {
+	return a.Path == b.Path && a.FilterOptions == b.FilterOptions && (a.PivotOptions != nil) == (b.PivotOptions != nil)
+}
+
+// readCustomEventFields reads the custom event fields back only if the
+// project declares any, so that those of a project that doesn't aren't
+// planned to be deleted.
+func readCustomEventFields(ctx context.Context, d *schema.ResourceData, c *Client) diag.Diagnostics {
+	if len(d.Get("custom_event_field").([]interface{})) == 0 {
+		return nil
+	}
+
+	fields, err := c.ListEventFields(ctx, d.Id())
+	if err != nil {
+		return apiErrorDiagnostics("Unable to list Bugsnag project event fields", err)
+	}
+
+	if err := d.Set("custom_event_field", flattenCustomEventFields(fields)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
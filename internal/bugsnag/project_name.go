@@ -0,0 +1,45 @@
+package bugsnag
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// maxProjectNameLength is the longest project name, in characters, the
+// Bugsnag dashboard accepts.
+const maxProjectNameLength = 255
+
+// validateProjectName rejects at plan time the names the API would reject
+// mid-apply: blank names, names that are too long, and names containing
+// control characters such as newlines or tabs.
+func validateProjectName(v interface{}, path cty.Path) diag.Diagnostics {
+	name := v.(string)
+
+	length := utf8.RuneCountInString(name)
+	control := strings.IndexFunc(name, unicode.IsControl)
+
+	var problem string
+	switch {
+	case strings.TrimSpace(name) == "":
+		problem = "The project name must not be blank."
+	case length > maxProjectNameLength:
+		problem = fmt.Sprintf("The project name is %d characters long, but must be at most %d.", length, maxProjectNameLength)
+	case control >= 0:
+		r, _ := utf8.DecodeRuneInString(name[control:])
+		problem = fmt.Sprintf("The project name must not contain control characters, but contains %q.", r)
+	default:
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       "Invalid project name",
+		Detail:        problem,
+		AttributePath: path,
+	}}
+}
@@ -0,0 +1,36 @@
+package bugsnag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestValidateProjectName(t *testing.T) {
+	path := cty.GetAttrPath("name")
+
+	for _, name := range []string{"web", "My App (Production)", "Ünïcode Näme", strings.Repeat("é", maxProjectNameLength)} {
+		if diags := validateProjectName(name, path); len(diags) != 0 {
+			t.Errorf("expected %q to be valid, got %v", name, diags)
+		}
+	}
+
+	cases := map[string]string{
+		"":    "blank",
+		"   ": "blank",
+		strings.Repeat("a", maxProjectNameLength+1): "at most",
+		"web\n":    `'\n'`,
+		"web\tapp": `'\t'`,
+	}
+	for name, problem := range cases {
+		diags := validateProjectName(name, path)
+		if !diags.HasError() {
+			t.Errorf("expected %q to be invalid", name)
+			continue
+		}
+		if !strings.Contains(diags[0].Detail, problem) {
+			t.Errorf("expected the diagnostic for %q to mention %s, got %q", name, problem, diags[0].Detail)
+		}
+	}
+}
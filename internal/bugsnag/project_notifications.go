@@ -0,0 +1,105 @@
+package bugsnag
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
+)
+
+// emailNotificationsSchema is the email_notifications block of
+// bugsnag_project. Left out, the project keeps the settings it has; set, its
+// unset arguments take Bugsnag's defaults.
+func emailNotificationsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Computed: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"new_errors": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+					Description: "Whether to email collaborators about new errors.",
+				},
+				"reopened_errors": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+					Description: "Whether to email collaborators about errors reopened after being resolved.",
+				},
+				"error_spikes": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+					Description: "Whether to email collaborators about errors spiking.",
+				},
+				"daily_summary": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Whether to email collaborators a daily summary of the project's errors.",
+				},
+			},
+		},
+		Description: "The emails Bugsnag sends about the project's errors. Left out, the project keeps the settings it has.",
+	}
+}
+
+func expandEmailNotifications(blocks []interface{}) *api.EmailNotificationSettings {
+	if len(blocks) == 0 || blocks[0] == nil {
+		return nil
+	}
+	block := blocks[0].(map[string]interface{})
+
+	settings := &api.EmailNotificationSettings{}
+	settings.NewErrors, _ = block["new_errors"].(bool)
+	settings.ReopenedErrors, _ = block["reopened_errors"].(bool)
+	settings.ErrorSpikes, _ = block["error_spikes"].(bool)
+	settings.DailySummary, _ = block["daily_summary"].(bool)
+	return settings
+}
+
+func flattenEmailNotifications(settings *api.EmailNotificationSettings) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"new_errors":      settings.NewErrors,
+			"reopened_errors": settings.ReopenedErrors,
+			"error_spikes":    settings.ErrorSpikes,
+			"daily_summary":   settings.DailySummary,
+		},
+	}
+}
+
+// updateEmailNotifications applies the email_notifications block of a
+// project, if it is set.
+func updateEmailNotifications(ctx context.Context, d *schema.ResourceData, c *Client) diag.Diagnostics {
+	settings := expandEmailNotifications(d.Get("email_notifications").([]interface{}))
+	if settings == nil {
+		return nil
+	}
+
+	if _, err := c.UpdateEmailNotificationSettings(ctx, d.Id(), settings); err != nil {
+		return apiErrorDiagnostics("Unable to update Bugsnag project email notifications", err)
+	}
+	return nil
+}
+
+func createEmailNotifications(d *schema.ResourceData, request *api.CreateProjectRequest) {
+	request.EmailNotifications = expandEmailNotifications(d.Get("email_notifications").([]interface{}))
+}
+
+func readEmailNotifications(ctx context.Context, d *schema.ResourceData, c *Client) diag.Diagnostics {
+	settings, err := c.GetEmailNotificationSettings(ctx, d.Id())
+	if err != nil {
+		return apiErrorDiagnostics("Unable to read Bugsnag project email notifications", err)
+	}
+
+	if err := d.Set("email_notifications", flattenEmailNotifications(settings)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package bugsnag
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
+)
+
+// reopenRulesSchema is the reopen_rules block of bugsnag_project, with which
+// resolved errors are reopened once they occur occurrences times within
+// hours. Left out, the project keeps the rules it has.
+func reopenRulesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Computed: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"enabled": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+					Description: "Whether resolved errors are reopened by these rules.",
+				},
+				"occurrences": {
+					Type:         schema.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "The number of occurrences of a resolved error, within `hours`, that reopen it.",
+				},
+				"hours": {
+					Type:         schema.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "The number of hours over which `occurrences` are counted.",
+				},
+			},
+		},
+		Description: "When resolved errors of the project are reopened. Left out, the project keeps the rules it has.",
+	}
+}
+
+func expandReopenRules(blocks []interface{}) *api.ReopenRules {
+	if len(blocks) == 0 || blocks[0] == nil {
+		return nil
+	}
+	block := blocks[0].(map[string]interface{})
+
+	rules := &api.ReopenRules{}
+	rules.Enabled, _ = block["enabled"].(bool)
+	rules.Occurrences, _ = block["occurrences"].(int)
+	rules.Hours, _ = block["hours"].(int)
+	return rules
+}
+
+func flattenReopenRules(rules *api.ReopenRules) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"enabled":     rules.Enabled,
+			"occurrences": rules.Occurrences,
+			"hours":       rules.Hours,
+		},
+	}
+}
+
+// updateReopenRules applies the reopen_rules block of a project, if it is
+// set.
+func updateReopenRules(ctx context.Context, d *schema.ResourceData, c *Client) diag.Diagnostics {
+	rules := expandReopenRules(d.Get("reopen_rules").([]interface{}))
+	if rules == nil {
+		return nil
+	}
+
+	if _, err := c.UpdateReopenRules(ctx, d.Id(), rules); err != nil {
+		return apiErrorDiagnostics("Unable to update Bugsnag project reopen rules", err)
+	}
+	return nil
+}
+
+func createReopenRules(d *schema.ResourceData, request *api.CreateProjectRequest) {
+	request.ReopenRules = expandReopenRules(d.Get("reopen_rules").([]interface{}))
+}
+
+func readReopenRules(ctx context.Context, d *schema.ResourceData, c *Client) diag.Diagnostics {
+	rules, err := c.GetReopenRules(ctx, d.Id())
+	if err != nil {
+		return apiErrorDiagnostics("Unable to read Bugsnag project reopen rules", err)
+	}
+
+	if err := d.Set("reopen_rules", flattenReopenRules(rules)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
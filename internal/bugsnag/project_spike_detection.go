@@ -0,0 +1,122 @@
+package bugsnag
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
+)
+
+// spikeDetectionSchema is the spike_detection block of bugsnag_project: an
+// error spikes when it occurs threshold_multiplier times more often than
+// usual over window_minutes, and at least minimum_events times. Left out,
+// the project keeps the settings it has; set, its unset arguments take
+// Bugsnag's defaults.
+func spikeDetectionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Computed: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"enabled": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+					Description: "Whether Bugsnag detects errors spiking.",
+				},
+				"threshold_multiplier": {
+					Type:         schema.TypeFloat,
+					Optional:     true,
+					Default:      4.0,
+					ValidateFunc: validation.FloatAtLeast(1),
+					Description:  "How many times more often than usual an error must occur to spike.",
+				},
+				"minimum_events": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      10,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "The number of events within `window_minutes` an error needs, at least, to spike.",
+				},
+				"window_minutes": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      60,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "The number of minutes over which the events of an error are counted.",
+				},
+				// whether spikes are sent to the project's integrations, such
+				// as an on-call pager; spike emails are set in
+				// email_notifications
+				"notify_integrations": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+					Description: "Whether spikes are sent to the project's integrations, such as an on-call pager. Spike emails are set in `email_notifications`.",
+				},
+			},
+		},
+		Description: "When Bugsnag considers an error of the project to be spiking. Left out, the project keeps the settings it has.",
+	}
+}
+
+func expandSpikeDetection(blocks []interface{}) *api.SpikeDetectionSettings {
+	if len(blocks) == 0 || blocks[0] == nil {
+		return nil
+	}
+	block := blocks[0].(map[string]interface{})
+
+	settings := &api.SpikeDetectionSettings{}
+	settings.Enabled, _ = block["enabled"].(bool)
+	settings.ThresholdMultiplier, _ = block["threshold_multiplier"].(float64)
+	settings.MinimumEvents, _ = block["minimum_events"].(int)
+	settings.WindowMinutes, _ = block["window_minutes"].(int)
+	settings.NotifyIntegrations, _ = block["notify_integrations"].(bool)
+	return settings
+}
+
+func flattenSpikeDetection(settings *api.SpikeDetectionSettings) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"enabled":              settings.Enabled,
+			"threshold_multiplier": settings.ThresholdMultiplier,
+			"minimum_events":       settings.MinimumEvents,
+			"window_minutes":       settings.WindowMinutes,
+			"notify_integrations":  settings.NotifyIntegrations,
+		},
+	}
+}
+
+// updateSpikeDetection applies the spike_detection block of a project, if it
+// is set.
+func updateSpikeDetection(ctx context.Context, d *schema.ResourceData, c *Client) diag.Diagnostics {
+	settings := expandSpikeDetection(d.Get("spike_detection").([]interface{}))
+	if settings == nil {
+		return nil
+	}
+
+	if _, err := c.UpdateSpikeDetectionSettings(ctx, d.Id(), settings); err != nil {
+		return apiErrorDiagnostics("Unable to update Bugsnag project spike detection", err)
+	}
+	return nil
+}
+
+func createSpikeDetection(d *schema.ResourceData, request *api.CreateProjectRequest) {
+	request.SpikeDetection = expandSpikeDetection(d.Get("spike_detection").([]interface{}))
+}
+
+func readSpikeDetection(ctx context.Context, d *schema.ResourceData, c *Client) diag.Diagnostics {
+	settings, err := c.GetSpikeDetectionSettings(ctx, d.Id())
+	if err != nil {
+		return apiErrorDiagnostics("Unable to read Bugsnag project spike detection", err)
+	}
+
+	if err := d.Set("spike_detection", flattenSpikeDetection(settings)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package bugsnag
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
+)
+
+// targetStabilitySchema and criticalStabilitySchema are the
+// target_stability and critical_stability arguments of bugsnag_project: the
+// percentage of sessions, or users, free of unhandled errors the project
+// aims for, and below which its stability is critical. Left out, the project
+// keeps the target it has.
+func targetStabilitySchema() *schema.Schema {
+	return stabilityTargetSchema("The percentage of sessions, or users, free of unhandled errors the project aims for. Left unset, the project keeps the target it has.")
+}
+
+func criticalStabilitySchema() *schema.Schema {
+	return stabilityTargetSchema("The percentage of sessions, or users, free of unhandled errors below which the stability of the project is critical. Left unset, the project keeps the target it has.")
+}
+
+func stabilityTargetSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeFloat,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.FloatBetween(0, 100),
+		Description:  description,
+	}
+}
+
+func updateTargetStability(ctx context.Context, d *schema.ResourceData, c *Client) diag.Diagnostics {
+	return updateStabilityTarget(ctx, d, c, "target_stability", func(targets *api.StabilityTargets, v float64) {
+		targets.TargetStability = &v
+	})
+}
+
+func updateCriticalStability(ctx context.Context, d *schema.ResourceData, c *Client) diag.Diagnostics {
+	return updateStabilityTarget(ctx, d, c, "critical_stability", func(targets *api.StabilityTargets, v float64) {
+		targets.CriticalStability = &v
+	})
+}
+
+// updateStabilityTarget applies the stability target name of a project, if
+// it is set, leaving the other target alone.
+func updateStabilityTarget(ctx context.Context, d *schema.ResourceData, c *Client, name string, set func(*api.StabilityTargets, float64)) diag.Diagnostics {
+	if !stabilityTargetConfigured(d, name) {
+		return nil
+	}
+
+	targets := &api.StabilityTargets{}
+	set(targets, d.Get(name).(float64))
+	if _, err := c.UpdateStabilityTargets(ctx, d.Id(), targets); err != nil {
+		return apiErrorDiagnostics("Unable to update Bugsnag project stability targets", err)
+	}
+	return nil
+}
+
+func createTargetStability(d *schema.ResourceData, request *api.CreateProjectRequest) {
+	createStabilityTarget(d, request, "target_stability", func(targets *api.StabilityTargets, v float64) {
+		targets.TargetStability = &v
+	})
+}
+
+func createCriticalStability(d *schema.ResourceData, request *api.CreateProjectRequest) {
+	createStabilityTarget(d, request, "critical_stability", func(targets *api.StabilityTargets, v float64) {
+		targets.CriticalStability = &v
+	})
+}
+
+// createStabilityTarget sets the stability target name of a new project, if
+// it is set; the target left unset starts with its default.
+func createStabilityTarget(d *schema.ResourceData, request *api.CreateProjectRequest, name string, set func(*api.StabilityTargets, float64)) {
+	if !stabilityTargetConfigured(d, name) {
+		return
+	}
+
+	if request.StabilityTargets == nil {
+		request.StabilityTargets = &api.StabilityTargets{}
+	}
+	set(request.StabilityTargets, d.Get(name).(float64))
+}
+
+// stabilityTargetConfigured reports whether the stability target name is in
+// the configuration, even as 0, which d.GetOk takes for unset.
+func stabilityTargetConfigured(d *schema.ResourceData, name string) bool {
+	v, diags := d.GetRawConfigAt(cty.GetAttrPath(name))
+	return !diags.HasError() && !v.IsNull()
+}
+
+// readStabilityTargets sets both stability targets of a project, which the
+// API serves together, so that a refresh reads them with a single request.
+func readStabilityTargets(ctx context.Context, d *schema.ResourceData, c *Client) diag.Diagnostics {
+	targets, err := c.GetStabilityTargets(ctx, d.Id())
+	if err != nil {
+		return apiErrorDiagnostics("Unable to read Bugsnag project stability targets", err)
+	}
+
+	for name, v := range map[string]*float64{
+		"target_stability":   targets.TargetStability,
+		"critical_stability": targets.CriticalStability,
+	} {
+		if v == nil {
+			continue
+		}
+		if err := d.Set(name, *v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+// projectStabilitySchema holds the current stability of the project, as
+// computed by Bugsnag, for bugsnag_project.
+func projectStabilitySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"user_stability": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "The current percentage of the project's users free of unhandled errors. Unset until the project has sessions.",
+		},
+		"session_stability": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "The current percentage of the project's sessions free of unhandled errors. Unset until the project has sessions.",
+		},
+	}
+}
+
+// readProjectStability sets the current stability of a project. It is left
+// unset until the project has sessions.
+func readProjectStability(ctx context.Context, d *schema.ResourceData, c *Client) diag.Diagnostics {
+	stability, err := c.GetProjectStability(ctx, d.Id())
+	if err != nil {
+		return apiErrorDiagnostics("Unable to read Bugsnag project stability", err)
+	}
+
+	if stability.UserStability != nil {
+		if err := d.Set("user_stability", *stability.UserStability); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if stability.SessionStability != nil {
+		if err := d.Set("session_stability", *stability.SessionStability); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+// eventUsageSchema holds the events the project received in the current
+// billing period against its allocation, for bugsnag_project.
+func eventUsageSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"events_used": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The number of events the project received in the current billing period.",
+		},
+		"event_quota": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The number of events allocated to the project for the current billing period. Unset if the project has no allocation of its own.",
+		},
+		"event_quota_used_percent": {
+			Type:        schema.TypeFloat,
+			Computed:    true,
+			Description: "The percentage of `event_quota` used so far. Unset if the project has no allocation of its own.",
+		},
+	}
+}
+
+// readEventUsage sets the event usage of a project. The quota, and the share
+// of it used, are left unset for a project with no allocation of its own.
+func readEventUsage(ctx context.Context, d *schema.ResourceData, c *Client) diag.Diagnostics {
+	usage, err := c.GetEventUsage(ctx, d.Id())
+	if err != nil {
+		return apiErrorDiagnostics("Unable to read Bugsnag project event usage", err)
+	}
+
+	if err := d.Set("events_used", int(usage.EventsUsed)); err != nil {
+		return diag.FromErr(err)
+	}
+	if usage.EventQuota != nil {
+		if err := d.Set("event_quota", int(*usage.EventQuota)); err != nil {
+			return diag.FromErr(err)
+		}
+		used := 0.0
+		if *usage.EventQuota > 0 {
+			used = float64(usage.EventsUsed) / float64(*usage.EventQuota) * 100
+		}
+		if err := d.Set("event_quota_used_percent", used); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package bugsnag
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// projectTypes are the project types the Bugsnag API accepts, as listed in
+// the dashboard's project creation form.
+var projectTypes = []string{
+	"android", "angular", "asgi", "aspnet", "aspnet_core", "backbone", "bottle",
+	"cocos2dx", "connect", "django", "dotnet", "dotnet_desktop", "dotnet_mvc",
+	"electron", "ember", "eventmachine", "expo", "express", "flask", "flutter",
+	"gin", "go", "go_net_http", "heroku", "ios", "java", "java_desktop", "js",
+	"koa", "laravel", "lumen", "magento", "martini", "minidump",
+	"nintendo_switch", "node", "osx", "other_desktop", "other_mobile",
+	"other_tv", "php", "python", "rack", "rails", "react", "reactnative",
+	"restify", "revel", "ruby", "silex", "sinatra", "spring", "symfony",
+	"tornado", "tvos", "unity", "unrealengine", "vue", "watchos", "webapi",
+	"wordpress", "wpf", "wsgi",
+}
+
+// maxProjectTypeSuggestionDistance is how many edits away from a known
+// project type a value may be for it to be suggested.
+const maxProjectTypeSuggestionDistance = 3
+
+// validateProjectType checks a project type against the known ones at plan
+// time, rather than leaving the API to reject it at apply, and suggests the
+// closest known type for near-misses such as "nodejs" or "react-native".
+func validateProjectType(v interface{}, path cty.Path) diag.Diagnostics {
+	value := v.(string)
+	for _, projectType := range projectTypes {
+		if value == projectType {
+			return nil
+		}
+	}
+
+	detail := fmt.Sprintf("%q is not a Bugsnag project type.", value)
+	if suggestion := suggestProjectType(value); suggestion != "" {
+		detail += fmt.Sprintf(" Did you mean %q?", suggestion)
+	}
+	detail += fmt.Sprintf("\n\nThe known project types are: %s.", joinQuoted(projectTypes))
+
+	return diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       "Invalid project type",
+		Detail:        detail,
+		AttributePath: path,
+	}}
+}
+
+// suggestProjectType returns the known project type closest to value, or ""
+// if none is close enough to be what was meant.
+func suggestProjectType(value string) string {
+	suggestion, best := "", maxProjectTypeSuggestionDistance+1
+	for _, projectType := range projectTypes {
+		if distance := editDistance(value, projectType); distance < best {
+			suggestion, best = projectType, distance
+		}
+	}
+	return suggestion
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func joinQuoted(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, fmt.Sprintf("%q", value))
+	}
+	return strings.Join(quoted, ", ")
+}
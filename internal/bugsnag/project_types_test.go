@@ -0,0 +1,37 @@
+package bugsnag
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestValidateProjectType(t *testing.T) {
+	path := cty.GetAttrPath("type")
+
+	if diags := validateProjectType("rails", path); len(diags) != 0 {
+		t.Fatalf("expected a known type to be valid, got %v", diags)
+	}
+
+	cases := map[string]string{
+		"nodejs":       `Did you mean "node"?`,
+		"react-native": `Did you mean "reactnative"?`,
+		"Rails":        `Did you mean "rails"?`,
+	}
+	for value, suggestion := range cases {
+		diags := validateProjectType(value, path)
+		if !diags.HasError() {
+			t.Errorf("expected %q to be invalid", value)
+			continue
+		}
+		if !strings.Contains(diags[0].Detail, suggestion) {
+			t.Errorf("expected %q to suggest %s, got %q", value, suggestion, diags[0].Detail)
+		}
+	}
+
+	diags := validateProjectType("cobol", path)
+	if !diags.HasError() || strings.Contains(diags[0].Detail, "Did you mean") {
+		t.Fatalf("expected no suggestion for an unrelated type, got %v", diags)
+	}
+}
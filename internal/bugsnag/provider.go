@@ -1,15 +1,19 @@
 package bugsnag
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"os/exec"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
 )
 
 func init() {
@@ -28,7 +32,28 @@ func init() {
 	// }
 }
 
-func New(version string) func() *schema.Provider {
+// Option customizes the provider returned by New.
+type Option func(*options)
+
+type options struct {
+	transport http.RoundTripper
+}
+
+// WithTransport replaces the HTTP transport of the clients the provider
+// configures, e.g. for acceptance tests to record and replay their API
+// interactions. A nil transport keeps the default one.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *options) {
+		o.transport = transport
+	}
+}
+
+func New(version string, opts ...Option) func() *schema.Provider {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	return func() *schema.Provider {
 		p := &schema.Provider{
 			Schema: map[string]*schema.Schema{
@@ -36,16 +61,210 @@ func New(version string) func() *schema.Provider {
 					Type:        schema.TypeString,
 					Optional:    true,
 					DefaultFunc: schema.EnvDefaultFunc("BUGSNAG_ORGANIZATION_ID", nil),
+					Description: "The ID of the Bugsnag organization to manage. When unset, the organization the API token belongs to is used. Can also be set with the `BUGSNAG_ORGANIZATION_ID` environment variable.",
 				},
 				"api_token": {
+					Type:          schema.TypeString,
+					Optional:      true,
+					Sensitive:     true,
+					DefaultFunc:   schema.EnvDefaultFunc("BUGSNAG_API_TOKEN", nil),
+					ConflictsWith: []string{"api_token_file", "credentials_command"},
+					Description:   "The token used to authenticate to the Bugsnag Data Access API. Can also be set with the `BUGSNAG_API_TOKEN` environment variable. Conflicts with `api_token_file` and `credentials_command`.",
+				},
+				"api_token_file": {
+					Type:          schema.TypeString,
+					Optional:      true,
+					DefaultFunc:   schema.EnvDefaultFunc("BUGSNAG_API_TOKEN_FILE", nil),
+					ConflictsWith: []string{"api_token", "credentials_command"},
+					Description:   "The path of a file holding the API token, such as a mounted secret. Surrounding whitespace is ignored. Can also be set with the `BUGSNAG_API_TOKEN_FILE` environment variable.",
+				},
+				"credentials_command": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MinItems: 1,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+					ConflictsWith: []string{"api_token", "api_token_file"},
+					Description:   "A command, as a list of the program and its arguments, that prints the API token to its standard output, such as a secrets manager CLI. It is run once, when the provider is configured.",
+				},
+				"auth_type": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("BUGSNAG_AUTH_TYPE", api.DefaultAuthType),
+					ValidateFunc: validation.StringInSlice([]string{"api_token", "personal_auth_token"}, false),
+					Description:  fmt.Sprintf("How the API token is sent: `api_token` for a data access token, or `personal_auth_token`. Defaults to `%s`. Can also be set with the `BUGSNAG_AUTH_TYPE` environment variable.", api.DefaultAuthType),
+				},
+				"skip_credentials_validation": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("BUGSNAG_SKIP_CREDENTIALS_VALIDATION", false),
+					Description: "Skip checking that the API token can access the organization before the first request. Can also be set with the `BUGSNAG_SKIP_CREDENTIALS_VALIDATION` environment variable.",
+				},
+				"default_project_type": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					DefaultFunc:      schema.EnvDefaultFunc("BUGSNAG_DEFAULT_PROJECT_TYPE", nil),
+					ValidateDiagFunc: validateProjectType,
+					Description:      "The `type` of the projects that don't set one. Can also be set with the `BUGSNAG_DEFAULT_PROJECT_TYPE` environment variable.",
+				},
+				"default_ignore_old_browsers": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("BUGSNAG_DEFAULT_IGNORE_OLD_BROWSERS", nil),
+					Description: "The `ignore_old_browsers` of the projects created without one. Left unset, they get the Bugsnag default. Can also be set with the `BUGSNAG_DEFAULT_IGNORE_OLD_BROWSERS` environment variable.",
+				},
+				"api_version": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("BUGSNAG_API_VERSION", api.DefaultAPIVersion),
+					Description: fmt.Sprintf("The Bugsnag API version requested with every request. Defaults to `%s`. Can also be set with the `BUGSNAG_API_VERSION` environment variable.", api.DefaultAPIVersion),
+				},
+				"otlp_endpoint": {
 					Type:        schema.TypeString,
 					Optional:    true,
-					Sensitive:   true,
-					DefaultFunc: schema.EnvDefaultFunc("BUGSNAG_API_TOKEN", nil),
+					DefaultFunc: schema.MultiEnvDefaultFunc([]string{"BUGSNAG_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_ENDPOINT"}, nil),
+					Description: "The OTLP/HTTP collector to export a trace span of every API operation to. Tracing is disabled when unset. Can also be set with the `BUGSNAG_OTLP_ENDPOINT` or `OTEL_EXPORTER_OTLP_ENDPOINT` environment variables.",
+				},
+				"skip_duplicate_name_check": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Create projects without first listing every project to reject a duplicate name, leaving the API to reject it. Faster in organizations with many projects.",
+				},
+				"custom_headers": {
+					Type:      schema.TypeMap,
+					Optional:  true,
+					Sensitive: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+					Description: "Headers sent with every request, such as those required by a gateway in front of Bugsnag On-premise.",
+				},
+				"region": {
+					Type:          schema.TypeString,
+					Optional:      true,
+					DefaultFunc:   schema.EnvDefaultFunc("BUGSNAG_REGION", nil),
+					ValidateFunc:  validation.StringInSlice([]string{"us", "eu"}, false),
+					ConflictsWith: []string{"base_url"},
+					Description:   "The data residency region of the organization: `us` or `eu`. Can also be set with the `BUGSNAG_REGION` environment variable. Conflicts with `base_url`.",
+				},
+				"base_url": {
+					Type:          schema.TypeString,
+					Optional:      true,
+					DefaultFunc:   schema.EnvDefaultFunc("BUGSNAG_BASE_URL", nil),
+					ValidateFunc:  validation.IsURLWithHTTPorHTTPS,
+					ConflictsWith: []string{"region"},
+					Description:   fmt.Sprintf("The URL of the Bugsnag API, such as that of a Bugsnag On-premise instance. Defaults to `%s`. Can also be set with the `BUGSNAG_BASE_URL` environment variable. Conflicts with `region`.", api.DefaultBaseURL),
+				},
+				"request_timeout": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("BUGSNAG_REQUEST_TIMEOUT", api.DefaultRequestTimeout.String()),
+					ValidateFunc: validateDuration,
+					Description:  fmt.Sprintf("How long a single request may take, as a duration such as `30s`. Defaults to `%s`. Can also be set with the `BUGSNAG_REQUEST_TIMEOUT` environment variable.", api.DefaultRequestTimeout),
+				},
+				"ca_cert_file": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("BUGSNAG_CA_CERT_FILE", nil),
+					Description: "The path of a PEM file of certificate authorities to trust in addition to the system ones, such as the private authority of a Bugsnag On-premise instance. Can also be set with the `BUGSNAG_CA_CERT_FILE` environment variable.",
+				},
+				"insecure_skip_verify": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("BUGSNAG_INSECURE_SKIP_VERIFY", false),
+					Description: "Skip verifying the certificate of the Bugsnag API. Only meant for testing; prefer `ca_cert_file`. Can also be set with the `BUGSNAG_INSECURE_SKIP_VERIFY` environment variable.",
+				},
+				"circuit_breaker_threshold": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      5,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "The number of consecutive failed requests after which the next requests fail immediately, rather than each waiting for the API. `0` disables the circuit breaker.",
+				},
+				"usage_summary": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Report a summary of the requests sent to the API so far, per endpoint, as a warning at the end of each operation that reads from the API.",
+				},
+				"max_idle_conns": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      api.DefaultMaxIdleConns,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "The number of idle connections to the API kept open for reuse.",
+				},
+				"idle_conn_timeout": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      api.DefaultIdleConnTimeout.String(),
+					ValidateFunc: validateDuration,
+					Description:  "How long an idle connection to the API is kept open, as a duration such as `90s`.",
+				},
+				"keep_alive": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      api.DefaultKeepAlive.String(),
+					ValidateFunc: validateDuration,
+					Description:  "The interval between keep-alive probes of the connections to the API, as a duration such as `30s`.",
+				},
+				"max_rate_limit_retries": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("BUGSNAG_MAX_RATE_LIMIT_RETRIES", api.DefaultMaxRateLimitRetries),
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  fmt.Sprintf("The number of times a rate limited request is retried. Defaults to `%d`. Can also be set with the `BUGSNAG_MAX_RATE_LIMIT_RETRIES` environment variable.", api.DefaultMaxRateLimitRetries),
+				},
+				"max_retries": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("BUGSNAG_MAX_RETRIES", api.DefaultMaxRetries),
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  fmt.Sprintf("The number of times a request that failed with a transient error, such as a `503` response, is retried. `0` disables these retries. Defaults to `%d`. Can also be set with the `BUGSNAG_MAX_RETRIES` environment variable.", api.DefaultMaxRetries),
+				},
+				"max_retry_elapsed_time": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("BUGSNAG_MAX_RETRY_ELAPSED_TIME", api.DefaultMaxRetryElapsedTime.String()),
+					ValidateFunc: validateDuration,
+					Description:  fmt.Sprintf("How long a request may be retried for, as a duration such as `2m`. Defaults to `%s`. Can also be set with the `BUGSNAG_MAX_RETRY_ELAPSED_TIME` environment variable.", api.DefaultMaxRetryElapsedTime),
+				},
+				"requests_per_minute": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("BUGSNAG_REQUESTS_PER_MINUTE", 0),
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "The number of requests per minute the provider sends at most, to leave some of the organization's rate limit to other clients. `0` means no limit. Can also be set with the `BUGSNAG_REQUESTS_PER_MINUTE` environment variable.",
+				},
+				"rate_limit_warning_threshold": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("BUGSNAG_RATE_LIMIT_WARNING_THRESHOLD", 10),
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "The remaining rate limit quota at or below which a warning is reported. `0` disables the warning. Can also be set with the `BUGSNAG_RATE_LIMIT_WARNING_THRESHOLD` environment variable.",
+				},
+				"max_response_size": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					DefaultFunc:  schema.EnvDefaultFunc("BUGSNAG_MAX_RESPONSE_SIZE", api.DefaultMaxResponseSize),
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  fmt.Sprintf("The size, in bytes, of the largest response accepted from the API. Defaults to `%d`. Can also be set with the `BUGSNAG_MAX_RESPONSE_SIZE` environment variable.", api.DefaultMaxResponseSize),
 				},
 			},
 			ResourcesMap: map[string]*schema.Resource{
-				"bugsnag_project": resourceProject(),
+				"bugsnag_project":                 resourceProject(),
+				"bugsnag_project_settings":        resourceProjectSettings(),
+				"bugsnag_project_bulk":            resourceProjectBulk(),
+				"bugsnag_collaborator":            resourceCollaborator(),
+				"bugsnag_collaborator_invitation": resourceCollaboratorInvitation(),
+				"bugsnag_team_project_assignment": resourceTeamProjectAssignment(),
+				"bugsnag_organization_admins":     resourceOrganizationAdmins(),
+				"bugsnag_allowed_email_domain":    resourceAllowedEmailDomain(),
+				"bugsnag_sso_configuration":       resourceSSOConfiguration(),
+				"bugsnag_webhook":                 resourceWebhook(),
+				"bugsnag_gitlab_integration":      resourceGitLabIntegration(),
 			},
 			DataSourcesMap: map[string]*schema.Resource{
 				"bugsnag_projects": dataSourceProjects(),
@@ -53,304 +272,201 @@ func New(version string) func() *schema.Provider {
 			},
 		}
 
-		p.ConfigureContextFunc = configure(version, p)
+		p.ConfigureContextFunc = configure(version, p, o)
 
 		return p
 	}
 }
 
-type apiClient struct {
-	HostURL        string
-	HTTPapiClient  *http.Client
-	OrganizationID string
-	APIToken       string
-}
+func configure(version string, p *schema.Provider, o options) func(c context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	return func(c context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		var diags diag.Diagnostics
 
-// NewapiClient -
-func NewapiClient(apiToken, organizationID string) *apiClient {
-	return &apiClient{
-		HTTPapiClient: &http.Client{Timeout: 10 * time.Second},
-		HostURL:       fmt.Sprintf("%s/%s", HostURL, organizationID),
-		APIToken:      apiToken,
-	}
-}
+		apiToken, diags := resolveAPIToken(c, d)
+		if diags.HasError() {
+			return nil, diags
+		}
 
-func (c *apiClient) doRequest(req *http.Request) (*http.Response, error) {
-	req.Header.Set("Authorization", fmt.Sprintf("token %s", c.APIToken))
-	return c.HTTPapiClient.Do(req)
-}
+		if apiToken == "" {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Bugsnag API Token not provided",
+				Detail: `You did not provide the Bugsnag API token used for authentication. 
+Please export the API token's value to $BUGSNAG_API_TOKEN, or configure api_token_file or credentials_command.
+For further, see https://bugsnagapiv2.docs.apiary.io/#introduction/authentication`,
+			})
+			return nil, diags
+		}
 
-func (c *apiClient) testAuth() (*http.Response, error) {
-	req, err := http.NewRequest("GET", c.HostURL, nil)
-	if err != nil {
-		return nil, err
-	}
-	return c.doRequest(req)
-}
+		requestTimeout, err := time.ParseDuration(d.Get("request_timeout").(string))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
 
-func (c *apiClient) listProjects() ([]map[string]interface{}, diag.Diagnostics) {
-	var diags diag.Diagnostics
+		maxRetryElapsedTime, err := time.ParseDuration(d.Get("max_retry_elapsed_time").(string))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/projects?per_page=100", c.HostURL), nil)
-	if err != nil {
-		return nil, diag.FromErr(err)
-	}
+		idleConnTimeout, err := time.ParseDuration(d.Get("idle_conn_timeout").(string))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
 
-	r, err := c.doRequest(req)
-	if err != nil {
-		return nil, diag.FromErr(err)
-	}
+		keepAlive, err := time.ParseDuration(d.Get("keep_alive").(string))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
 
-	// https://bugsnagapiv2.docs.apiary.io/#reference/projects/projects/list-an-organization's-projects
-	if r.StatusCode != 200 {
-		switch r.StatusCode {
-		case 429:
+		insecureSkipVerify := d.Get("insecure_skip_verify").(bool)
+		tlsConfig, err := api.NewTLSConfig(d.Get("ca_cert_file").(string), insecureSkipVerify)
+		if err != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
-				Summary:  "rate limit reached",
-				Detail: `You have reached the rate limit, please try again later.
-For further, see https://bugsnagapiv2.docs.apiary.io/#introduction/rate-limiting.`,
+				Summary:  "Unable to configure TLS",
+				Detail:   err.Error(),
 			})
 			return nil, diags
-		default:
-			defer r.Body.Close()
-
-			body, err := ioutil.ReadAll(r.Body)
-			if err != nil {
-				panic(err.Error())
-			}
-
+		}
+		if insecureSkipVerify {
 			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "unexpected error",
-				Detail: fmt.Sprintf(`You have encountered an unexpected error.
-Please see https://bugsnagapiv2.docs.apiary.io/#reference/projects/projects/list-an-organization's-projects for further information
-error message: %s`, string(body)),
+				Severity: diag.Warning,
+				Summary:  "TLS certificate verification is disabled",
+				Detail: `insecure_skip_verify is set, so the Bugsnag API's certificate will not be verified.
+Prefer ca_cert_file to trust a private certificate authority.`,
 			})
-			return nil, diags
 		}
-	}
 
-	defer r.Body.Close()
-
-	projects := make([]map[string]interface{}, 0)
-	err = json.NewDecoder(r.Body).Decode(&projects)
-	if err != nil {
-		return nil, diag.FromErr(err)
+		client := &Client{
+			BugsnagAPI: api.NewClient(api.ClientConfig{
+				BaseURL:             resolveBaseURL(d),
+				APIToken:            apiToken,
+				AuthType:            d.Get("auth_type").(string),
+				OrganizationID:      d.Get("organization_id").(string),
+				RequestTimeout:      requestTimeout,
+				TLSConfig:           tlsConfig,
+				MaxRateLimitRetries: d.Get("max_rate_limit_retries").(int),
+				MaxRetries:          d.Get("max_retries").(int),
+				MaxRetryElapsedTime: maxRetryElapsedTime,
+				RequestsPerMinute:   d.Get("requests_per_minute").(int),
+				MaxResponseSize:     int64(d.Get("max_response_size").(int)),
+				MaxIdleConns:        d.Get("max_idle_conns").(int),
+				IdleConnTimeout:     idleConnTimeout,
+				KeepAlive:           keepAlive,
+
+				CircuitBreakerThreshold:   d.Get("circuit_breaker_threshold").(int),
+				UsageSummary:              d.Get("usage_summary").(bool),
+				RateLimitWarningThreshold: d.Get("rate_limit_warning_threshold").(int),
+				ValidateCredentials:       !d.Get("skip_credentials_validation").(bool),
+				CustomHeaders:             expandStringMap(d.Get("custom_headers").(map[string]interface{})),
+				UserAgent:                 userAgent(version, p.TerraformVersion),
+				APIVersion:                d.Get("api_version").(string),
+				OTLPEndpoint:              d.Get("otlp_endpoint").(string),
+				ProviderVersion:           version,
+				Transport:                 o.transport,
+			}),
+			DefaultProjectType:     d.Get("default_project_type").(string),
+			SkipDuplicateNameCheck: d.Get("skip_duplicate_name_check").(bool),
+		}
+		if v, ok := d.GetOkExists("default_ignore_old_browsers"); ok {
+			ignoreOldBrowsers := v.(bool)
+			client.DefaultIgnoreOldBrowsers = &ignoreOldBrowsers
+		}
+		return client, diags
 	}
-
-	return projects, diags
 }
 
-func (c *apiClient) getProject(projectID string) (map[string]interface{}, diag.Diagnostics) {
+// resolveAPIToken returns the API token from api_token, api_token_file or
+// the output of credentials_command, whichever is configured.
+func resolveAPIToken(ctx context.Context, d *schema.ResourceData) (string, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/projects/%s", c.HostURL, projectID), nil)
-	if err != nil {
-		return nil, diag.FromErr(err)
-	}
-	r, err := c.doRequest(req)
-	if err != nil {
-		return nil, diag.FromErr(err)
-	}
-
-	if r.StatusCode != 200 {
-		defer r.Body.Close()
-
-		body, err := ioutil.ReadAll(r.Body)
+	if tokenFile := d.Get("api_token_file").(string); tokenFile != "" {
+		token, err := ioutil.ReadFile(tokenFile)
 		if err != nil {
-			panic(err.Error())
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Unable to read Bugsnag API token file",
+				Detail:   fmt.Sprintf(`Unable to read the API token from %s: %s`, tokenFile, err),
+			})
+			return "", diags
 		}
-
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "unexpected error",
-			Detail: fmt.Sprintf(`You have encountered an unexpected error.
-Please see https://bugsnagapiv2.docs.apiary.io/#reference/projects/projects/create-a-project-in-an-organization for further information
-error message: %s`, string(body)),
-		})
-		return nil, diags
-	}
-
-	defer r.Body.Close()
-
-	project := make(map[string]interface{}, 0)
-	err = json.NewDecoder(r.Body).Decode(&project)
-	if err != nil {
-		return nil, diag.FromErr(err)
-	}
-
-	return project, diags
-}
-
-func (c *apiClient) createProject(name, projectType string, ignore_old_browsers bool) (string, diag.Diagnostics) {
-	var diags diag.Diagnostics
-
-	url_params := fmt.Sprintf("?name=%s&type=%s&ignore_old_browsers=%v", name, projectType, ignore_old_browsers)
-
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/projects%s", c.HostURL, url_params), nil)
-	if err != nil {
-		return "", diag.FromErr(err)
+		return strings.TrimSpace(string(token)), diags
 	}
 
-	r, err := c.doRequest(req)
-	if err != nil {
-		return "", diag.FromErr(err)
-	}
+	if args := d.Get("credentials_command").([]interface{}); len(args) > 0 {
+		argv := make([]string, len(args))
+		for i, arg := range args {
+			argv[i], _ = arg.(string)
+		}
 
-	if r.StatusCode != 200 {
-		defer r.Body.Close()
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+		cmd.Stderr = &stderr
 
-		body, err := ioutil.ReadAll(r.Body)
+		out, err := cmd.Output()
 		if err != nil {
-			panic(err.Error())
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Bugsnag credentials command failed",
+				Detail: fmt.Sprintf(`Running %s to retrieve the API token failed: %s
+stderr: %s`, argv[0], err, strings.TrimSpace(stderr.String())),
+			})
+			return "", diags
 		}
-
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "unexpected error",
-			Detail: fmt.Sprintf(`You have encountered an unexpected error.
-Please see https://bugsnagapiv2.docs.apiary.io/#reference/projects/projects/create-a-project-in-an-organization for further information
-error message: %s`, string(body)),
-		})
-		return "", diags
-	}
-
-	defer r.Body.Close()
-
-	project := make(map[string]interface{}, 0)
-	err = json.NewDecoder(r.Body).Decode(&project)
-	if err != nil {
-		return "", diag.FromErr(err)
+		return strings.TrimSpace(string(out)), diags
 	}
 
-	id := project["id"].(string)
-
-	if len(id) == 0 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "no project ID retrieved",
-			Detail: fmt.Sprintf(`no project ID was retrieved.
-received response body: %v`, project),
-		})
-		return "", diags
-	}
-
-	return id, diags
+	return d.Get("api_token").(string), diags
 }
 
-func (c *apiClient) updateProject(name, projectType string, ignore_old_browsers bool) (string, diag.Diagnostics) {
-	var diags diag.Diagnostics
-
-	url_params := fmt.Sprintf("?name=%s&type=%s&ignore_old_browsers=%v", name, projectType, ignore_old_browsers)
-
-	req, err := http.NewRequest("PATCH", fmt.Sprintf("%s/projects%s", c.HostURL, url_params), nil)
-	if err != nil {
-		return "", diag.FromErr(err)
+// resolveBaseURL returns the API host to use, preferring an explicit base_url over
+// the host of the configured region.
+func resolveBaseURL(d *schema.ResourceData) string {
+	if v := d.Get("base_url").(string); v != "" {
+		return v
 	}
 
-	r, err := c.doRequest(req)
-	if err != nil {
-		return "", diag.FromErr(err)
+	if v, ok := api.RegionBaseURLs[d.Get("region").(string)]; ok {
+		return v
 	}
 
-	if r.StatusCode != 200 {
-		defer r.Body.Close()
-
-		body, err := ioutil.ReadAll(r.Body)
-		if err != nil {
-			panic(err.Error())
-		}
+	return api.DefaultBaseURL
+}
 
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "unexpected error",
-			Detail: fmt.Sprintf(`You have encountered an unexpected error.
-Please see https://bugsnagapiv2.docs.apiary.io/#reference/projects/projects/create-a-project-in-an-organization for further information
-error message: %s`, string(body)),
-		})
-		return "", diags
+// userAgent identifies the provider and the Terraform CLI driving it in the
+// API's audit logs.
+func userAgent(providerVersion, terraformVersion string) string {
+	if terraformVersion == "" {
+		// Terraform 0.12 and later always report their version.
+		terraformVersion = "0.11+compatible"
 	}
+	return fmt.Sprintf("terraform-provider-bugsnag/%s terraform/%s", providerVersion, terraformVersion)
+}
 
-	defer r.Body.Close()
-
-	project := make(map[string]interface{}, 0)
-	err = json.NewDecoder(r.Body).Decode(&project)
-	if err != nil {
-		return "", diag.FromErr(err)
+func expandStringMap(m map[string]interface{}) map[string]string {
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[k] = v.(string)
 	}
+	return result
+}
 
-	id := project["id"].(string)
-
-	if len(id) == 0 {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "no project ID retrieved",
-			Detail: fmt.Sprintf(`no project ID was retrieved.
-received response body: %v`, project),
-		})
-		return "", diags
+func expandStringList(l []interface{}) []string {
+	result := make([]string, 0, len(l))
+	for _, v := range l {
+		result = append(result, v.(string))
 	}
-
-	return id, diags
+	return result
 }
 
-func configure(version string, p *schema.Provider) func(c context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	return func(c context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-		var diags diag.Diagnostics
-
-		apiToken := d.Get("api_token").(string)
-		if apiToken == "" {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Bugsnag API Token not provided",
-				Detail: `You did not provide the Bugsnag API token used for authentication. 
-Please export the API token's value to $BUGSNAG_API_TOKEN.
-For further, see https://bugsnagapiv2.docs.apiary.io/#introduction/authentication`,
-			})
-			return nil, diags
-		}
-
-		organizationID := d.Get("organization_id").(string)
-		if organizationID == "" {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Bugsnag organization ID not provided",
-				Detail: `You did not provide the Bugsnag organization ID.
-To get the value, ask your administrator or send an authenticated request to https://api.bugsnag.com/user/organizations.
-Please provide it in the provider block or export the API token's value to $BUGSNAG_ORGANIZATION_ID.
-For further, see https://bugsnagapiv2.docs.apiary.io/#reference/current-user/organizations/list-the-current-user's-organizations.`,
-			})
-			return nil, diags
-		}
-
-		client := NewapiClient(apiToken, organizationID)
-		r, err := client.testAuth()
-		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to authenticate to Bugsnag",
-				Detail:   fmt.Sprintf(`Unexpected error: %s`, err),
-			})
-			return nil, diags
-		} else if r.StatusCode == 429 {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "API rate limit exceeded",
-				Detail: `You have reached Bugsnag's API rate limit.
-Please wait a moment and try again.`,
-			})
-			return nil, diags
-		} else if r.StatusCode != 200 {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  "Unable to authenticate to Bugsnag",
-				Detail: fmt.Sprintf(`Unable to authenticate to Bugsnag API (%s) with the provided API token.
-Please check that your token is valid and try again.`, client.HostURL),
-			})
-			return nil, diags
-		}
+func expandStringSet(s *schema.Set) []string {
+	return expandStringList(s.List())
+}
 
-		return c, diags
+func validateDuration(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a duration such as \"30s\" or \"2m\": %s", k, err))
 	}
+	return
 }
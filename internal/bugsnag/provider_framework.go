@@ -0,0 +1,224 @@
+package bugsnag
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// NewMuxServer serves the SDKv2 provider returned by New together with the
+// terraform-plugin-framework provider as a single protocol version 6
+// provider, so new resources can be written with the framework without
+// rewriting the existing ones. The options apply to both providers, which
+// share the SDKv2 provider's client.
+func NewMuxServer(ctx context.Context, version string, opts ...Option) (func() tfprotov6.ProviderServer, error) {
+	primary := New(version, opts...)()
+
+	upgradedSdkServer, err := tf5to6server.UpgradeServer(ctx, primary.GRPCProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	muxServer, err := tf6muxserver.NewMuxServer(ctx,
+		// the SDKv2 provider must come first: it is configured first and the
+		// framework provider reuses the client it builds
+		func() tfprotov6.ProviderServer { return upgradedSdkServer },
+		providerserver.NewProtocol6(NewFrameworkProvider(version, primary)()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return muxServer.ProviderServer, nil
+}
+
+// frameworkProvider holds the resources, data sources and other features
+// built on terraform-plugin-framework.
+type frameworkProvider struct {
+	version string
+
+	// primary is the SDKv2 provider muxed alongside this one. It owns the
+	// provider configuration.
+	primary *schema.Provider
+}
+
+var (
+	_ provider.ProviderWithFunctions          = &frameworkProvider{}
+	_ provider.ProviderWithEphemeralResources = &frameworkProvider{}
+)
+
+func NewFrameworkProvider(version string, primary *schema.Provider) func() provider.Provider {
+	return func() provider.Provider {
+		return &frameworkProvider{
+			version: version,
+			primary: primary,
+		}
+	}
+}
+
+func (p *frameworkProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "bugsnag"
+	resp.Version = p.version
+}
+
+func (p *frameworkProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = frameworkProviderSchema(p.primary.Schema)
+}
+
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	// the mux server has already configured the SDKv2 provider from the same
+	// configuration, so its client is shared instead of building another one
+	meta := p.primary.Meta()
+	if meta == nil {
+		return
+	}
+
+	resp.DataSourceData = meta
+	resp.ResourceData = meta
+	resp.EphemeralResourceData = meta
+}
+
+func (p *frameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *frameworkProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		newAccessTokenEphemeralResource,
+		newProjectAPIKeyEphemeralResource,
+	}
+}
+
+func (p *frameworkProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		newSlugifyFunction,
+		newIsValidAPIKeyFunction,
+	}
+}
+
+// frameworkProviderSchema mirrors the SDKv2 provider schema, since every
+// muxed provider must report the same provider configuration schema.
+func frameworkProviderSchema(s map[string]*schema.Schema) fwschema.Schema {
+	attributes := make(map[string]fwschema.Attribute, len(s))
+	for name, sch := range s {
+		attributes[name] = frameworkProviderAttribute(sch)
+	}
+
+	return fwschema.Schema{Attributes: attributes}
+}
+
+func frameworkProviderAttribute(s *schema.Schema) fwschema.Attribute {
+	switch s.Type {
+	case schema.TypeBool:
+		return fwschema.BoolAttribute{
+			Required:            s.Required,
+			Optional:            s.Optional,
+			Sensitive:           s.Sensitive,
+			MarkdownDescription: s.Description,
+			DeprecationMessage:  s.Deprecated,
+		}
+	case schema.TypeInt:
+		return fwschema.Int64Attribute{
+			Required:            s.Required,
+			Optional:            s.Optional,
+			Sensitive:           s.Sensitive,
+			MarkdownDescription: s.Description,
+			DeprecationMessage:  s.Deprecated,
+		}
+	case schema.TypeFloat:
+		return fwschema.Float64Attribute{
+			Required:            s.Required,
+			Optional:            s.Optional,
+			Sensitive:           s.Sensitive,
+			MarkdownDescription: s.Description,
+			DeprecationMessage:  s.Deprecated,
+		}
+	case schema.TypeList:
+		return fwschema.ListAttribute{
+			ElementType:         frameworkElementType(s.Elem),
+			Required:            s.Required,
+			Optional:            s.Optional,
+			Sensitive:           s.Sensitive,
+			MarkdownDescription: s.Description,
+			DeprecationMessage:  s.Deprecated,
+		}
+	case schema.TypeSet:
+		return fwschema.SetAttribute{
+			ElementType:         frameworkElementType(s.Elem),
+			Required:            s.Required,
+			Optional:            s.Optional,
+			Sensitive:           s.Sensitive,
+			MarkdownDescription: s.Description,
+			DeprecationMessage:  s.Deprecated,
+		}
+	case schema.TypeMap:
+		return fwschema.MapAttribute{
+			ElementType:         frameworkElementType(s.Elem),
+			Required:            s.Required,
+			Optional:            s.Optional,
+			Sensitive:           s.Sensitive,
+			MarkdownDescription: s.Description,
+			DeprecationMessage:  s.Deprecated,
+		}
+	default:
+		return fwschema.StringAttribute{
+			Required:            s.Required,
+			Optional:            s.Optional,
+			Sensitive:           s.Sensitive,
+			MarkdownDescription: s.Description,
+			DeprecationMessage:  s.Deprecated,
+		}
+	}
+}
+
+// frameworkElementType returns the element type of a primitive collection.
+// Collections without an explicit element schema hold strings.
+func frameworkElementType(elem interface{}) attr.Type {
+	s, ok := elem.(*schema.Schema)
+	if !ok {
+		return types.StringType
+	}
+
+	switch s.Type {
+	case schema.TypeBool:
+		return types.BoolType
+	case schema.TypeInt:
+		return types.Int64Type
+	case schema.TypeFloat:
+		return types.Float64Type
+	default:
+		return types.StringType
+	}
+}
+
+// frameworkDiagnostics converts the diagnostics returned by Client methods
+// for use in framework-based resources.
+func frameworkDiagnostics(diags diag.Diagnostics) fwdiag.Diagnostics {
+	var converted fwdiag.Diagnostics
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			converted.AddError(d.Summary, d.Detail)
+		} else {
+			converted.AddWarning(d.Summary, d.Detail)
+		}
+	}
+	return converted
+}
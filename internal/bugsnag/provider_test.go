@@ -1,28 +1,52 @@
 package bugsnag
 
 import (
+	"context"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-// providerFactories are used to instantiate a provider during acceptance testing.
-// The factory function will be invoked for every Terraform CLI command executed
-// to create a provider server to which the CLI can reattach.
-var providerFactories = map[string]func() (*schema.Provider, error){
-	"bugsnag": func() (*schema.Provider, error) {
-		return New("dev")(), nil
-	},
-}
-
 func TestProvider(t *testing.T) {
 	if err := New("dev")().InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
 	}
 }
 
-func testAccPreCheck(t *testing.T) {
-	// You can add code here to run prior to any test case execution, for example assertions
-	// about the appropriate environment variables being set are common to see in a pre-check
-	// function.
+func TestSchemaDescriptions(t *testing.T) {
+	p := New("dev")()
+	checkSchemaDescriptions(t, "provider", p.Schema)
+	for name, r := range p.ResourcesMap {
+		if r.Description == "" {
+			t.Errorf("%s has no description", name)
+		}
+		checkSchemaDescriptions(t, name, r.Schema)
+	}
+	for name, r := range p.DataSourcesMap {
+		if r.Description == "" {
+			t.Errorf("data source %s has no description", name)
+		}
+		checkSchemaDescriptions(t, "data source "+name, r.Schema)
+	}
+}
+
+// checkSchemaDescriptions fails the test for each attribute of s, and of its
+// nested blocks, without a description.
+func checkSchemaDescriptions(t *testing.T, path string, s map[string]*schema.Schema) {
+	t.Helper()
+	for name, attribute := range s {
+		if attribute.Description == "" {
+			t.Errorf("%s.%s has no description", path, name)
+		}
+		if block, ok := attribute.Elem.(*schema.Resource); ok {
+			checkSchemaDescriptions(t, path+"."+name, block.Schema)
+		}
+	}
+}
+
+func TestMuxServer(t *testing.T) {
+	// the mux server rejects providers whose schemas disagree
+	if _, err := NewMuxServer(context.Background(), "dev"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
 }
@@ -0,0 +1,143 @@
+package bugsnag
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
+)
+
+// requestOptionsSchema is the request_options block, with which a resource
+// overrides the provider's timeout and retry policy for its own requests,
+// e.g. for projects behind a slower gateway.
+func requestOptionsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"request_timeout": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validateDuration,
+					Description:  "How long a single request of this resource may take, as a duration such as `30s`. Defaults to the provider's `request_timeout`, or to the operation's timeout when it is set.",
+				},
+				"max_retries": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "The number of times a request of this resource that failed with a transient error is retried. Defaults to the provider's `max_retries`; `0` turns retrying off.",
+				},
+				"max_rate_limit_retries": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "The number of times a rate limited request of this resource is retried. Defaults to the provider's `max_rate_limit_retries`; `0` turns retrying off.",
+				},
+				"max_retry_elapsed_time": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validateDuration,
+					Description:  "How long a request of this resource may be retried for, as a duration such as `2m`. Defaults to the provider's `max_retry_elapsed_time`.",
+				},
+			},
+		},
+		Description: "Overrides of the provider's timeout and retry settings for the requests of this resource.",
+	}
+}
+
+// defaultResourceTimeout is the default of every operation in a resource's
+// timeouts block, the same as the SDK's.
+const defaultResourceTimeout = 20 * time.Minute
+
+// resourceTimeouts returns the timeouts block of a resource, whose
+// operations can be given longer than the provider's request_timeout, e.g.
+// against a slow on-premise instance.
+func resourceTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(defaultResourceTimeout),
+		Read:   schema.DefaultTimeout(defaultResourceTimeout),
+		Update: schema.DefaultTimeout(defaultResourceTimeout),
+		Delete: schema.DefaultTimeout(defaultResourceTimeout),
+	}
+}
+
+// withRequestOptions returns a context in which the client applies the
+// resource's request_options. Settings left unset keep the provider's.
+//
+// The SDK already bounds the context by the timeout of the operation named
+// timeoutKey. When that timeout is configured, it also replaces the
+// provider's request_timeout, so that a single slow request can use all of
+// it; a request_timeout in request_options still takes precedence.
+func withRequestOptions(ctx context.Context, d *schema.ResourceData, timeoutKey string) context.Context {
+	var options api.RequestOptions
+
+	blocks, _ := d.Get("request_options").([]interface{})
+	if len(blocks) > 0 && blocks[0] != nil {
+		block := blocks[0].(map[string]interface{})
+
+		// the durations were validated; unset durations are empty, which
+		// keeps the provider's
+		requestTimeout, _ := block["request_timeout"].(string)
+		maxRetryElapsedTime, _ := block["max_retry_elapsed_time"].(string)
+
+		options.RequestTimeout, _ = time.ParseDuration(requestTimeout)
+		options.MaxRetryElapsedTime, _ = time.ParseDuration(maxRetryElapsedTime)
+		options.MaxRetries = configuredRetries(d, block, "max_retries")
+		options.MaxRateLimitRetries = configuredRetries(d, block, "max_rate_limit_retries")
+	}
+
+	if options.RequestTimeout == 0 && timeoutConfigured(d, timeoutKey) {
+		options.RequestTimeout = d.Timeout(timeoutKey)
+	}
+
+	if options == (api.RequestOptions{}) {
+		return ctx
+	}
+	return api.WithRequestOptions(ctx, options)
+}
+
+// configuredRetries returns the retries named key of the request_options
+// block, or nil when they are left unset. Since 0 turns retrying off, unset
+// retries are told apart with the configuration; without one, e.g. when
+// refreshing, the state holds 0 for both and 0 keeps the provider's.
+func configuredRetries(d *schema.ResourceData, block map[string]interface{}, key string) *int {
+	retries, _ := block[key].(int)
+
+	if !d.GetRawConfig().IsNull() {
+		v, diags := d.GetRawConfigAt(cty.GetAttrPath("request_options").IndexInt(0).GetAttr(key))
+		if diags.HasError() || v.IsNull() {
+			return nil
+		}
+		return &retries
+	}
+
+	if retries == 0 {
+		return nil
+	}
+	return &retries
+}
+
+// timeoutConfigured reports whether the operation named timeoutKey has a
+// timeout in the resource's timeouts block, even one equal to the default.
+// The block is read from the configuration, or from the state, which keeps
+// it, when there is no configuration, e.g. when refreshing.
+func timeoutConfigured(d *schema.ResourceData, timeoutKey string) bool {
+	raw := d.GetRawConfig()
+	if raw.IsNull() {
+		raw = d.GetRawState()
+	}
+	if raw.IsNull() || !raw.IsKnown() || !raw.Type().HasAttribute(schema.TimeoutsConfigKey) {
+		return false
+	}
+
+	timeouts := raw.GetAttr(schema.TimeoutsConfigKey)
+	if timeouts.IsNull() || !timeouts.IsKnown() || !timeouts.Type().HasAttribute(timeoutKey) {
+		return false
+	}
+	return !timeouts.GetAttr(timeoutKey).IsNull()
+}
@@ -0,0 +1,99 @@
+package bugsnag
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-bugsnag/internal/bugsnagtest"
+)
+
+func TestResourceProjectRequestOptions(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	projectsPath := "/organizations/" + bugsnagtest.OrganizationID + "/projects"
+
+	c := newTestClient(server)
+
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"name": "api",
+		"request_options": []interface{}{
+			map[string]interface{}{
+				"request_timeout":        "30s",
+				"max_rate_limit_retries": 3,
+				"max_retry_elapsed_time": time.Minute.String(),
+			},
+		},
+	})
+
+	// the provider-level client only retries rate limited requests once
+	server.Fail("GET", projectsPath, 429, 429)
+	if diags := resourceProjectCreate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("expected the resource's retry policy to be used, got %v", diags)
+	}
+}
+
+func TestResourceProjectRequestOptionsTurnOffRetries(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	projectsPath := "/organizations/" + bugsnagtest.OrganizationID + "/projects"
+
+	c := newTestClient(server)
+
+	d := testResourceData(t, resourceProject(), nil, map[string]interface{}{
+		"name": "api",
+		"request_options": []interface{}{
+			map[string]interface{}{
+				"max_rate_limit_retries": 0,
+			},
+		},
+	}, c)
+
+	// the provider-level client would retry this rate limited request once
+	server.Fail("GET", projectsPath, 429)
+	if diags := resourceProjectCreate(context.Background(), d, c); !diags.HasError() {
+		t.Fatalf("expected the rate limited request not to be retried")
+	}
+}
+
+func TestWithRequestOptionsWithoutBlock(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"name": "api",
+	})
+
+	ctx := context.Background()
+	if withRequestOptions(ctx, d, schema.TimeoutRead) != ctx {
+		t.Fatalf("expected the context to be left alone without request_options or timeouts")
+	}
+}
+
+func TestResourceProjectTimeouts(t *testing.T) {
+	timeouts := resourceProject().Timeouts
+	if timeouts == nil || timeouts.Create == nil || timeouts.Read == nil || timeouts.Update == nil || timeouts.Delete == nil {
+		t.Fatalf("expected a timeout for every operation, got %v", timeouts)
+	}
+}
+
+func TestTimeoutConfigured(t *testing.T) {
+	d := testResourceData(t, resourceProject(), nil, map[string]interface{}{
+		"name": "api",
+		"timeouts": map[string]interface{}{
+			// the default, yet configured on purpose
+			"create": "20m",
+		},
+	}, nil)
+	if !timeoutConfigured(d, schema.TimeoutCreate) {
+		t.Fatalf("expected the create timeout to be configured")
+	}
+	if timeoutConfigured(d, schema.TimeoutRead) {
+		t.Fatalf("expected the read timeout not to be configured")
+	}
+
+	d = testResourceData(t, resourceProject(), nil, map[string]interface{}{
+		"name": "api",
+	}, nil)
+	if timeoutConfigured(d, schema.TimeoutCreate) {
+		t.Fatalf("expected no timeout to be configured without a timeouts block")
+	}
+}
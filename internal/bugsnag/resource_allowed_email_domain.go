@@ -0,0 +1,114 @@
+package bugsnag
+
+import (
+	"context"
+	"errors"
+	"log"
+	"regexp"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
+)
+
+// resourceAllowedEmailDomain allows one email domain for invitations to the
+// organization. Domains are added and removed one at a time, so that several
+// of these resources, in one configuration or many, don't overwrite each
+// other's domains.
+func resourceAllowedEmailDomain() *schema.Resource {
+	return &schema.Resource{
+		Description: "Allows people to be invited to the Bugsnag organization with an email of a domain. " +
+			"Once the organization allows any domain, people can only be invited with an email of an allowed domain; " +
+			"destroying the last of these resources lifts the restriction.",
+		CreateContext: resourceAllowedEmailDomainCreate,
+		ReadContext:   resourceAllowedEmailDomainRead,
+		UpdateContext: resourceAllowedEmailDomainUpdate,
+		DeleteContext: resourceAllowedEmailDomainDelete,
+		Importer: &schema.ResourceImporter{
+			// the ID is the domain
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(emailDomainPattern, "must be a lowercase domain name, e.g. example.com, without the @"),
+				Description:  "The email domain to allow, e.g. `example.com`.",
+			},
+			"request_options": requestOptionsSchema(),
+		},
+	}
+}
+
+// emailDomainPattern matches the domain names Bugsnag accepts, which it
+// stores in lowercase.
+var emailDomainPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)+$`)
+
+func resourceAllowedEmailDomainCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutCreate)
+
+	domain := d.Get("domain").(string)
+	if err := c.AddAllowedEmailDomain(ctx, domain); err != nil {
+		return apiErrorDiagnostics("Unable to allow the email domain", err)
+	}
+
+	d.SetId(domain)
+
+	return resourceAllowedEmailDomainRead(ctx, d, m)
+}
+
+func resourceAllowedEmailDomainRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutRead)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	domains, err := c.ListAllowedEmailDomains(ctx)
+	if err != nil {
+		return apiErrorDiagnostics("Unable to list the allowed email domains", err)
+	}
+
+	if !slices.Contains(domains, d.Id()) {
+		if d.IsNewResource() {
+			return diag.Errorf("the email domain %s wasn't allowed", d.Id())
+		}
+		log.Printf("[WARN] allowed email domain %s not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	}
+
+	if err := d.Set("domain", d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return append(diags, rateLimitDiagnostics(c)...)
+}
+
+// resourceAllowedEmailDomainUpdate only saves request_options: changing the
+// domain replaces the resource.
+func resourceAllowedEmailDomainUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceAllowedEmailDomainRead(ctx, d, m)
+}
+
+func resourceAllowedEmailDomainDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutDelete)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	// domains already removed outside of Terraform need no removing
+	if err := c.RemoveAllowedEmailDomain(ctx, d.Id()); err != nil && !errors.Is(err, api.ErrNotFound) {
+		return apiErrorDiagnostics("Unable to remove the allowed email domain", err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,113 @@
+package bugsnag
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-bugsnag/internal/bugsnagtest"
+)
+
+func TestResourceAllowedEmailDomainCRUD(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	d := schema.TestResourceDataRaw(t, resourceAllowedEmailDomain().Schema, map[string]interface{}{
+		"domain": "example.com",
+	})
+	d.MarkNewResource()
+
+	if diags := resourceAllowedEmailDomainCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "example.com" {
+		t.Fatalf("unexpected ID %q", d.Id())
+	}
+	if domains := server.AllowedEmailDomains(); len(domains) != 1 || domains[0] != "example.com" {
+		t.Fatalf("expected the domain to be allowed, got %v", domains)
+	}
+
+	// invitations are restricted to the allowed domain
+	invitation := schema.TestResourceDataRaw(t, resourceCollaboratorInvitation().Schema, map[string]interface{}{
+		"email": "someone@elsewhere.com",
+	})
+	invitation.MarkNewResource()
+	if diags := resourceCollaboratorInvitationCreate(ctx, invitation, c); !diags.HasError() {
+		t.Fatalf("expected inviting an email of another domain to fail")
+	}
+
+	if diags := resourceAllowedEmailDomainDelete(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if domains := server.AllowedEmailDomains(); len(domains) != 0 {
+		t.Fatalf("expected the domain to be removed, got %v", domains)
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceAllowedEmailDomain().Schema, map[string]interface{}{
+		"domain": "example.com",
+	})
+	d.SetId("example.com")
+	if diags := resourceAllowedEmailDomainRead(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected a removed domain to be removed from state")
+	}
+}
+
+func TestResourceAllowedEmailDomainUpdateRequestOptions(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	d := schema.TestResourceDataRaw(t, resourceAllowedEmailDomain().Schema, map[string]interface{}{
+		"domain": "example.com",
+	})
+	d.MarkNewResource()
+	if diags := resourceAllowedEmailDomainCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	requests := len(server.Requests())
+
+	d = schema.TestResourceDataRaw(t, resourceAllowedEmailDomain().Schema, map[string]interface{}{
+		"domain": "example.com",
+		"request_options": []interface{}{
+			map[string]interface{}{
+				"max_rate_limit_retries": 3,
+			},
+		},
+	})
+	d.SetId("example.com")
+	if diags := resourceAllowedEmailDomainUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	for _, request := range server.Requests()[requests:] {
+		if !strings.HasPrefix(request, "GET ") {
+			t.Fatalf("expected changing request_options to only read the domain back, got %s", request)
+		}
+	}
+	if domains := server.AllowedEmailDomains(); len(domains) != 1 || domains[0] != "example.com" {
+		t.Fatalf("expected the domain to stay allowed, got %v", domains)
+	}
+}
+
+func TestEmailDomainPattern(t *testing.T) {
+	for domain, valid := range map[string]bool{
+		"example.com":        true,
+		"mail.example.co.uk": true,
+		"my-company.io":      true,
+		"localhost":          false,
+		"@example.com":       false,
+		"Example.com":        false,
+		"-example.com":       false,
+		"example..com":       false,
+	} {
+		if emailDomainPattern.MatchString(domain) != valid {
+			t.Errorf("expected %q to be valid: %t", domain, valid)
+		}
+	}
+}
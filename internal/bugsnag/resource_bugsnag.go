@@ -2,102 +2,628 @@ package bugsnag
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
 )
 
 func resourceProject() *schema.Resource {
+	// the read-only attributes shared with the data sources, less their
+	// telemetry, overridden by the arguments of the same name
+	s := getProjectSchema(true, true, true)
+	for k, v := range projectArgumentsSchema() {
+		s[k] = v
+	}
+	s["name"].ValidateDiagFunc = validateProjectName
+	s["type"].ValidateDiagFunc = validateProjectType
+	// the API ignores type in updates, so a project of another type is a new
+	// project; unsetting type keeps the existing one rather than replacing it
+	// with one of the provider's default_project_type
+	s["type"].ForceNew = true
+	s["type"].Description = "The type of the project, i.e. the platform or framework it uses, such as `rails` or `android`. Defaults to the provider's `default_project_type`. Changing it replaces the project."
+	for _, setting := range projectSettings {
+		s[setting.name] = setting.schema()
+	}
+	for k, v := range projectStabilitySchema() {
+		s[k] = v
+	}
+	for k, v := range eventUsageSchema() {
+		s[k] = v
+	}
+	s["request_options"] = requestOptionsSchema()
+
 	return &schema.Resource{
+		Description:   "Manages a Bugsnag project and its settings.",
 		CreateContext: resourceProjectCreate,
 		ReadContext:   resourceProjectRead,
 		UpdateContext: resourceProjectUpdate,
 		DeleteContext: resourceProjectDelete,
-		Schema:        getProjectSchema(true, true, true),
+		Importer: &schema.ResourceImporter{
+			// resourceProjectRead populates every attribute from the ID
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: resourceProjectCustomizeDiff,
+		Timeouts:      resourceTimeouts(),
+		Schema:        s,
 	}
 }
 
+// projectArgumentsSchema holds the project settings bugsnag_project manages.
+// Those stored by Bugsnag are Computed as well as Optional, so that the
+// settings left unset keep the values Bugsnag defaults them to.
+func projectArgumentsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"release_stages": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Description: "The release stages, such as `production`, the project receives errors from. Left unset, the project keeps the stages Bugsnag defaults it to; set it to `[]` to remove them all.",
+		},
+		"url_whitelist": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Set:         hashURLWhitelistEntry,
+			Description: "The URLs of the pages errors are accepted from, for browser projects. Entries are compared as Bugsnag normalizes them, ignoring case and trailing slashes. Left unset, the project keeps its whitelist; set it to `[]` to accept errors from every URL.",
+		},
+		"global_grouping": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Description: "The error classes to group into a single error regardless of where they occur. Left unset, the project keeps its rules; set it to `[]` to remove them all.",
+		},
+		"location_grouping": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Description: "The error classes to group by where they occur, regardless of their message. Left unset, the project keeps its rules; set it to `[]` to remove them all.",
+		},
+		"ignore_old_browsers": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+			Description: "Whether errors from the old browser versions listed in `ignored_browser_versions` are ignored. Defaults to the provider's `default_ignore_old_browsers`, if set; there is no need to set it for projects that don't run in browsers.",
+		},
+		"resolve_on_deploy": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+			Description: "Whether to resolve errors when a new version of the project is deployed.",
+		},
+		"collaborators_can_modify_settings": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+			Description: "Whether collaborators who aren't organization admins may change the settings of the project. Left unset, the project keeps the organization's default.",
+		},
+		"language": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "The programming language of the project, for the project types used with several languages.",
+		},
+		"adopt_existing": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Manage the existing project of the same name, if there is one, instead of failing to create a duplicate. Its settings are updated to match the configuration.",
+		},
+		"ignore_remote_renames": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Keep the name a project is given in the Bugsnag dashboard instead of planning to rename it back to `name`. Renaming the project in the configuration still renames it.",
+		},
+		"fetch_statistics": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Whether to read `user_stability`, `session_stability` and the event usage of the project, which take requests of their own. Set to `false` to skip them and speed up refreshes; they then keep their last values.",
+		},
+		"key_rotation_serial": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Change this value to regenerate the notifier `api_key` of the project. The previous key stops working right away.",
+		},
+	}
+}
+
+// projectSetting is an argument of bugsnag_project, usually a block, that the
+// API serves apart from the project, with requests of its own.
+type projectSetting struct {
+	name   string
+	schema func() *schema.Schema
+	// update applies the argument, if it is set
+	update func(ctx context.Context, d *schema.ResourceData, c *Client) diag.Diagnostics
+	// read sets the argument from the API; it is nil for the arguments kept
+	// as configured, and for those read along with another
+	read func(ctx context.Context, d *schema.ResourceData, c *Client) diag.Diagnostics
+	// create sets the argument, if it is set, in the request creating the
+	// project, instead of applying it with update once the project exists;
+	// it is nil for the arguments the API doesn't take on creation
+	create func(d *schema.ResourceData, request *api.CreateProjectRequest)
+}
+
+var projectSettings = []projectSetting{
+	{"email_notifications", emailNotificationsSchema, updateEmailNotifications, readEmailNotifications, createEmailNotifications},
+	{"reopen_rules", reopenRulesSchema, updateReopenRules, readReopenRules, createReopenRules},
+	{"spike_detection", spikeDetectionSchema, updateSpikeDetection, readSpikeDetection, createSpikeDetection},
+	{"target_stability", targetStabilitySchema, updateTargetStability, readStabilityTargets, createTargetStability},
+	// read along with target_stability
+	{"critical_stability", criticalStabilitySchema, updateCriticalStability, nil, createCriticalStability},
+	{"custom_event_field", customEventFieldSchema, updateCustomEventFields, readCustomEventFields, nil},
+	{"collaborators", collaboratorsSchema, updateCollaborators, nil, nil},
+}
+
 func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutCreate)
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
 	name := d.Get("name").(string)
 	project_type := d.Get("type").(string)
-	ignore_old_browsers := d.Get("ignore_old_browsers").(bool)
-
-	projects, diags := c.listProjects()
-	if len(diags) > 0 {
+	if project_type == "" {
+		project_type = c.DefaultProjectType
+	}
+	if project_type == "" {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "project type not set",
+			Detail:   fmt.Sprintf(`the project %s has no type; please set type on the project, or default_project_type on the provider.`, name),
+		})
 		return diags
 	}
 
-	for _, project := range projects {
-		if project["name"] == name {
+	adoptExisting := d.Get("adopt_existing").(bool)
+	if !c.SkipDuplicateNameCheck || adoptExisting {
+		existing, err := c.FindProjectsByName(ctx, name)
+		if err != nil {
+			return apiErrorDiagnostics("Unable to list Bugsnag projects", err)
+		}
+		if len(existing) == 1 && adoptExisting {
+			return resourceProjectAdopt(ctx, d, m, existing[0], project_type)
+		}
+		if len(existing) > 0 {
+			detail := fmt.Sprintf(`the project %s already exists!`, name)
+			if adoptExisting {
+				detail = fmt.Sprintf(`%d projects are named %s, so adopt_existing can't tell which one to manage; please import it by ID instead.`, len(existing), name)
+			} else {
+				detail += ` Set adopt_existing to manage it with Terraform instead.`
+			}
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
 				Summary:  "project already exists",
-				Detail:   fmt.Sprintf(`the project %s already exists!`, name),
+				Detail:   detail,
 			})
 
 			return diags
 		}
 	}
 
-	projectID, diags := c.createProject(name, project_type, ignore_old_browsers)
-	if len(diags) > 0 {
-		return diags
+	request := &api.CreateProjectRequest{
+		Name:              name,
+		Type:              project_type,
+		Language:          d.Get("language").(string),
+		IgnoreOldBrowsers: c.DefaultIgnoreOldBrowsers,
+		ReleaseStages:     configuredStringSet(d, "release_stages"),
+		URLWhitelist:      configuredStringSet(d, "url_whitelist"),
+		GlobalGrouping:    configuredStringSet(d, "global_grouping"),
+		LocationGrouping:  configuredStringSet(d, "location_grouping"),
+	}
+	// unset, the project gets the provider's default, or else the API's
+	if v, ok := d.GetOkExists("ignore_old_browsers"); ok {
+		ignoreOldBrowsers := v.(bool)
+		request.IgnoreOldBrowsers = &ignoreOldBrowsers
+	}
+	// unset, the project keeps the organization's default
+	if v, ok := d.GetOkExists("resolve_on_deploy"); ok {
+		resolveOnDeploy := v.(bool)
+		request.ResolveOnDeploy = &resolveOnDeploy
+	}
+	if v, ok := d.GetOkExists("collaborators_can_modify_settings"); ok {
+		canModify := v.(bool)
+		request.CollaboratorsCanModifySettings = &canModify
+	}
+	// the settings the API takes on creation are created along with the
+	// project, so that it never exists without them
+	for _, setting := range projectSettings {
+		if setting.create != nil {
+			setting.create(d, request)
+		}
 	}
 
-	d.SetId(projectID)
-	resourceProjectRead(ctx, d, m)
-	return diags
+	project, err := c.CreateProject(ctx, request)
+	if err != nil {
+		return apiErrorDiagnostics("Unable to create Bugsnag project", err)
+	}
+
+	d.SetId(project.ID)
+
+	for _, setting := range projectSettings {
+		if setting.create != nil {
+			continue
+		}
+		if diags := setting.update(ctx, d, c); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceProjectRead(ctx, d, m)
+}
+
+// resourceProjectAdopt manages an existing project instead of creating one,
+// applying the settings given in the configuration to it.
+func resourceProjectAdopt(ctx context.Context, d *schema.ResourceData, m interface{}, project *api.Project, projectType string) diag.Diagnostics {
+	c := m.(*Client)
+
+	// the type of a project can't be changed, so the next plan would
+	// replace the adopted project
+	if project.Type != projectType {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "existing project has another type",
+			Detail:   fmt.Sprintf(`the existing project %s has type %s, not %s; adopting it would replace it on the next apply.`, project.Name, project.Type, projectType),
+		}}
+	}
+
+	// only the configured settings are applied; as when creating a project,
+	// those left unset keep their values
+	request := &api.UpdateProjectRequest{}
+	if v, ok := d.GetOk("language"); ok {
+		language := v.(string)
+		request.Language = &language
+	}
+	if v, ok := d.GetOkExists("ignore_old_browsers"); ok {
+		ignoreOldBrowsers := v.(bool)
+		request.IgnoreOldBrowsers = &ignoreOldBrowsers
+	}
+	if v, ok := d.GetOkExists("resolve_on_deploy"); ok {
+		resolveOnDeploy := v.(bool)
+		request.ResolveOnDeploy = &resolveOnDeploy
+	}
+	if v, ok := d.GetOkExists("collaborators_can_modify_settings"); ok {
+		canModify := v.(bool)
+		request.CollaboratorsCanModifySettings = &canModify
+	}
+	request.ReleaseStages = configuredStringSet(d, "release_stages")
+	request.URLWhitelist = configuredStringSet(d, "url_whitelist")
+	request.GlobalGrouping = configuredStringSet(d, "global_grouping")
+	request.LocationGrouping = configuredStringSet(d, "location_grouping")
+
+	log.Printf("[INFO] adopting the existing Bugsnag project %s", project.ID)
+	if _, err := c.UpdateProject(ctx, project.ID, request); err != nil {
+		return apiErrorDiagnostics("Unable to update Bugsnag project", err)
+	}
+
+	d.SetId(project.ID)
+
+	for _, setting := range projectSettings {
+		if diags := setting.update(ctx, d, c); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceProjectRead(ctx, d, m)
 }
 
 func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutRead)
 
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
 	projectID := d.Id()
 
-	project, diags := c.getProject(projectID)
-	if len(diags) > 0 {
+	var project *api.Project
+	var err error
+	if d.IsNewResource() {
+		project, err = getCreatedProject(ctx, c, projectID)
+	} else {
+		project, err = c.GetProject(ctx, projectID)
+	}
+	// a project deleted outside of Terraform is removed from state, so that
+	// the plan proposes creating it again; a project just created must exist
+	if errors.Is(err, api.ErrNotFound) && !d.IsNewResource() {
+		log.Printf("[WARN] Bugsnag project %s not found, removing it from state", projectID)
+		d.SetId("")
 		return diags
 	}
+	if err != nil {
+		return apiErrorDiagnostics("Unable to read Bugsnag project", err)
+	}
 
-	diags = append(diags, diag.Diagnostic{
-		Severity: diag.Warning,
-		Summary:  "test",
-		Detail:   fmt.Sprintf("hello %s", project),
-	})
+	flattened := flattenProject(project)
+	if name := d.Get("name").(string); name != "" && name != project.Name && !d.IsNewResource() {
+		diags = append(diags, remoteRenameDiagnostic(d, name, project.Name))
+		if d.Get("ignore_remote_renames").(bool) {
+			flattened["name"] = name
+		}
+	}
 
-	for v := range getProjectSchema(true, false, true) {
-		if err := d.Set(v, project[v]); err != nil {
+	for k, v := range flattened {
+		if err := d.Set(k, v); err != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
 				Summary:  "error reading project state",
-				Detail: fmt.Sprintf(`error message: %v
-project: %v`, err, project),
+				Detail: fmt.Sprintf(`Unable to set the %q attribute of project %s from the %T returned by the Bugsnag API: %v
+Please report this issue to the provider developers.`, k, projectID, v, err),
 			})
 			return diags
 		}
 	}
 
-	return diags
+	for _, setting := range projectSettings {
+		if setting.read == nil {
+			continue
+		}
+		if diags := setting.read(ctx, d, c); diags.HasError() {
+			return diags
+		}
+	}
+	if d.Get("fetch_statistics").(bool) {
+		if diags := readProjectStability(ctx, d, c); diags.HasError() {
+			return diags
+		}
+		if diags := readEventUsage(ctx, d, c); diags.HasError() {
+			return diags
+		}
+	}
+
+	return append(diags, rateLimitDiagnostics(c)...)
+}
+
+// remoteRenameDiagnostic explains the plan to rename back a project renamed
+// in the dashboard, which would otherwise show up as an unexplained change
+// of its name.
+func remoteRenameDiagnostic(d *schema.ResourceData, name, remoteName string) diag.Diagnostic {
+	detail := fmt.Sprintf(`The Bugsnag project %s was renamed from %q to %q outside of Terraform, e.g. in the Bugsnag dashboard.`, d.Id(), name, remoteName)
+	if d.Get("ignore_remote_renames").(bool) {
+		detail += ` The new name is kept, since ignore_remote_renames is set.`
+	} else {
+		detail += fmt.Sprintf(` The next apply renames it back to %q: update name to keep the new name, or set ignore_remote_renames.`, name)
+	}
+
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  "Bugsnag project renamed outside of Terraform",
+		Detail:   detail,
+	}
+}
+
+// readAfterCreateAttempts bounds how many times a project just created is
+// read before giving up on it, and readAfterCreateBackoff is the wait before
+// the first retry, doubled before each of the next ones.
+const readAfterCreateAttempts = 5
+
+var readAfterCreateBackoff = 500 * time.Millisecond
+
+// getCreatedProject reads a project just created. The API is eventually
+// consistent, so reading a fresh project occasionally fails with a 404 for a
+// moment: those reads are retried.
+func getCreatedProject(ctx context.Context, c *Client, projectID string) (*api.Project, error) {
+	backoff := readAfterCreateBackoff
+	for attempt := 1; ; attempt++ {
+		project, err := c.GetProject(ctx, projectID)
+		if !errors.Is(err, api.ErrNotFound) || attempt == readAfterCreateAttempts {
+			return project, err
+		}
+
+		log.Printf("[DEBUG] Bugsnag project %s not found yet, retrying in %s", projectID, backoff)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
 }
 
 func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutUpdate)
+
+	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
-	return diags
+	// the project and each of its settings are updated with requests of
+	// their own: when some fail, the others are still applied, and only the
+	// failed ones are restored to their prior values, so that the next
+	// apply retries them
+	var failed []string
+
+	request := &api.UpdateProjectRequest{}
+	var changed []string
+	if d.HasChange("name") {
+		name := d.Get("name").(string)
+		request.Name = &name
+		changed = append(changed, "name")
+	}
+	if d.HasChange("language") {
+		language := d.Get("language").(string)
+		request.Language = &language
+		changed = append(changed, "language")
+	}
+	if d.HasChange("release_stages") {
+		releaseStages := expandStringSet(d.Get("release_stages").(*schema.Set))
+		request.ReleaseStages = &releaseStages
+		changed = append(changed, "release_stages")
+	}
+	if d.HasChange("url_whitelist") {
+		urlWhitelist := expandStringSet(d.Get("url_whitelist").(*schema.Set))
+		request.URLWhitelist = &urlWhitelist
+		changed = append(changed, "url_whitelist")
+	}
+	if d.HasChange("ignore_old_browsers") {
+		ignoreOldBrowsers := d.Get("ignore_old_browsers").(bool)
+		request.IgnoreOldBrowsers = &ignoreOldBrowsers
+		changed = append(changed, "ignore_old_browsers")
+	}
+	if d.HasChange("resolve_on_deploy") {
+		resolveOnDeploy := d.Get("resolve_on_deploy").(bool)
+		request.ResolveOnDeploy = &resolveOnDeploy
+		changed = append(changed, "resolve_on_deploy")
+	}
+	if d.HasChange("collaborators_can_modify_settings") {
+		canModify := d.Get("collaborators_can_modify_settings").(bool)
+		request.CollaboratorsCanModifySettings = &canModify
+		changed = append(changed, "collaborators_can_modify_settings")
+	}
+	if d.HasChange("global_grouping") {
+		globalGrouping := expandStringSet(d.Get("global_grouping").(*schema.Set))
+		request.GlobalGrouping = &globalGrouping
+		changed = append(changed, "global_grouping")
+	}
+	if d.HasChange("location_grouping") {
+		locationGrouping := expandStringSet(d.Get("location_grouping").(*schema.Set))
+		request.LocationGrouping = &locationGrouping
+		changed = append(changed, "location_grouping")
+	}
+
+	// changes to the arguments that only exist in Terraform, or that are
+	// updated with requests of their own, need no project update
+	except := []string{"request_options", "key_rotation_serial", "adopt_existing", "ignore_remote_renames", "fetch_statistics"}
+	for _, setting := range projectSettings {
+		except = append(except, setting.name)
+	}
+	if d.HasChangesExcept(except...) {
+		if _, err := c.UpdateProject(ctx, d.Id(), request); err != nil {
+			diags = append(diags, apiErrorDiagnostics("Unable to update Bugsnag project", err)...)
+			failed = append(failed, changed...)
+		}
+	}
+
+	for _, setting := range projectSettings {
+		if !d.HasChange(setting.name) {
+			continue
+		}
+		if settingDiags := setting.update(ctx, d, c); settingDiags.HasError() {
+			diags = append(diags, settingDiags...)
+			failed = append(failed, setting.name)
+		}
+	}
+
+	// the new key is read back below
+	if d.HasChange("key_rotation_serial") {
+		if _, err := c.RegenerateProjectAPIKey(ctx, d.Id()); err != nil {
+			diags = append(diags, apiErrorDiagnostics("Unable to regenerate the Bugsnag project API key", err)...)
+			failed = append(failed, "key_rotation_serial")
+		}
+	}
+
+	if diags.HasError() {
+		return append(diags, restorePriorValues(d, failed...)...)
+	}
+
+	return resourceProjectRead(ctx, d, m)
+}
+
+// restorePriorValues sets arguments back to their values in the prior
+// state. Their changes failed to apply, and would otherwise be saved to the
+// state as applied, and never be retried.
+func restorePriorValues(d *schema.ResourceData, keys ...string) diag.Diagnostics {
+	for _, k := range keys {
+		prior, _ := d.GetChange(k)
+		if err := d.Set(k, prior); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
 }
 
 func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutDelete)
+
 	// Warning or errors can be collected in a slice type
 	var diags diag.Diagnostics
 
+	// projects already deleted outside of Terraform need no deleting
+	if err := c.DeleteProject(ctx, d.Id()); err != nil && !errors.Is(err, api.ErrNotFound) {
+		return apiErrorDiagnostics("Unable to delete Bugsnag project", err)
+	}
+
+	d.SetId("")
+
 	return diags
 }
+
+// resourceProjectCustomizeDiff shows api_key as changing when a change to
+// key_rotation_serial will regenerate it. A new project gets its first key
+// on creation, whatever the serial. It also rejects renaming a project to
+// the name of another, which the API would only reject on apply.
+func resourceProjectCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && d.HasChange("name") && d.NewValueKnown("name") {
+		if err := checkRenameConflict(ctx, m.(*Client), d.Id(), d.Get("name").(string)); err != nil {
+			return err
+		}
+	}
+	if d.Id() != "" && d.HasChange("key_rotation_serial") {
+		return d.SetNewComputed("api_key")
+	}
+	return nil
+}
+
+// checkRenameConflict returns an error if a project other than projectID is
+// named name. As on creation, the check is left to the API with
+// skip_duplicate_name_check.
+func checkRenameConflict(ctx context.Context, c *Client, projectID, name string) error {
+	if c.SkipDuplicateNameCheck {
+		return nil
+	}
+
+	existing, err := c.FindProjectsByName(ctx, name)
+	if err != nil {
+		return fmt.Errorf("unable to check for Bugsnag projects named %s: %w", name, err)
+	}
+	for _, project := range existing {
+		if project.ID != projectID {
+			return fmt.Errorf("unable to rename the project to %s: the project %s already has that name", name, project.ID)
+		}
+	}
+	return nil
+}
+
+// configuredStringSet returns the set named key when it is in the
+// configuration, even as [], or nil when it is left unset. d.GetOk can't
+// tell an empty set from an unset one.
+func configuredStringSet(d *schema.ResourceData, key string) *[]string {
+	if v, diags := d.GetRawConfigAt(cty.GetAttrPath(key)); diags.HasError() || v.IsNull() {
+		return nil
+	}
+	values := expandStringSet(d.Get(key).(*schema.Set))
+	return &values
+}
+
+// hashURLWhitelistEntry hashes url_whitelist entries as Bugsnag normalizes
+// them, so that entries it normalizes to the same value are the same
+// element: it lowercases entries and drops surrounding whitespace and
+// trailing slashes.
+func hashURLWhitelistEntry(v interface{}) int {
+	return schema.HashString(normalizeURLWhitelistEntry(v.(string)))
+}
+
+func normalizeURLWhitelistEntry(entry string) string {
+	return strings.TrimRight(strings.ToLower(strings.TrimSpace(entry)), "/")
+}
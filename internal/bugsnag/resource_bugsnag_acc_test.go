@@ -0,0 +1,57 @@
+package bugsnag_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-provider-bugsnag/pkg/acctest"
+)
+
+func TestAccResourceProject(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		CheckDestroy:             acctest.CheckProjectDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccResourceProject,
+				Check: resource.ComposeTestCheckFunc(
+					acctest.CheckProjectExists("bugsnag_project.test"),
+					resource.TestCheckResourceAttr("bugsnag_project.test", "name", "tf-acc-test-project"),
+					resource.TestCheckResourceAttr("bugsnag_project.test", "type", "go"),
+					resource.TestCheckResourceAttrSet("bugsnag_project.test", "api_key"),
+					resource.TestCheckResourceAttrSet("bugsnag_project.test", "html_url"),
+				),
+			},
+			{
+				Config: testAccResourceProjectUpdated,
+				Check: resource.ComposeTestCheckFunc(
+					acctest.CheckProjectExists("bugsnag_project.test"),
+					resource.TestCheckResourceAttr("bugsnag_project.test", "name", "tf-acc-test-project-renamed"),
+					resource.TestCheckResourceAttr("bugsnag_project.test", "release_stages.#", "2"),
+					resource.TestCheckTypeSetElemAttr("bugsnag_project.test", "release_stages.*", "staging"),
+				),
+			},
+			{
+				ResourceName:      "bugsnag_project.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testAccResourceProject = `
+resource "bugsnag_project" "test" {
+  name = "tf-acc-test-project"
+  type = "go"
+}
+`
+
+const testAccResourceProjectUpdated = `
+resource "bugsnag_project" "test" {
+  name           = "tf-acc-test-project-renamed"
+  type           = "go"
+  release_stages = ["production", "staging"]
+}
+`
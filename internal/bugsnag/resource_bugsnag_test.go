@@ -1,32 +1,1285 @@
 package bugsnag
 
 import (
+	"context"
 	"regexp"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-bugsnag/internal/bugsnagtest"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
 )
 
-func TestAccResourceBugsnag(t *testing.T) {
-	t.Skip("resource not yet implemented, remove this once you add your own code")
-
-	resource.UnitTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
-		Steps: []resource.TestStep{
-			{
-				Config: testAccResourceBugsnag,
-				Check: resource.ComposeTestCheckFunc(
-					resource.TestMatchResourceAttr(
-						"bugsnag_resource.foo", "sample_attribute", regexp.MustCompile("^ba")),
-				),
+func TestResourceProjectCreateUsesDefaultProjectType(t *testing.T) {
+	fake := &fakeAPI{}
+	c := &Client{BugsnagAPI: fake, DefaultProjectType: "go"}
+
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"name": "api",
+	})
+
+	if diags := resourceProjectCreate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if len(fake.created) != 1 || fake.created[0].Type != "go" {
+		t.Fatalf("expected a go project to be created, got %+v", fake.created)
+	}
+	if d.Id() != "1" {
+		t.Fatalf("expected the ID of the created project, got %q", d.Id())
+	}
+	if d.Get("type").(string) != "go" {
+		t.Fatalf("expected the type to be read back, got %q", d.Get("type"))
+	}
+}
+
+func TestResourceProjectCreateIgnoreOldBrowsers(t *testing.T) {
+	enabled := true
+	tests := map[string]struct {
+		config   map[string]interface{}
+		fallback *bool
+		want     *bool
+	}{
+		"unset":              {config: map[string]interface{}{}},
+		"provider default":   {config: map[string]interface{}{}, fallback: &enabled, want: &enabled},
+		"set on the project": {config: map[string]interface{}{"ignore_old_browsers": false}, fallback: &enabled, want: new(bool)},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			fake := &fakeAPI{}
+			c := &Client{BugsnagAPI: fake, DefaultIgnoreOldBrowsers: tt.fallback}
+
+			tt.config["name"] = "api"
+			tt.config["type"] = "go"
+			d := schema.TestResourceDataRaw(t, resourceProject().Schema, tt.config)
+
+			if diags := resourceProjectCreate(context.Background(), d, c); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			got := fake.created[0].IgnoreOldBrowsers
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Fatalf("expected ignore_old_browsers %v to be sent, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestResourceProjectCreateRejectsDuplicateNames(t *testing.T) {
+	fake := &fakeAPI{projects: []*api.Project{{ID: "1", Name: "api", Type: "go"}}}
+	c := &Client{BugsnagAPI: fake}
+
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"name": "api",
+		"type": "go",
+	})
+
+	if diags := resourceProjectCreate(context.Background(), d, c); !diags.HasError() {
+		t.Fatalf("expected an error for a duplicate project name")
+	}
+	if len(fake.created) != 0 {
+		t.Fatalf("expected no project to be created, got %+v", fake.created)
+	}
+
+	c.SkipDuplicateNameCheck = true
+	if diags := resourceProjectCreate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if len(fake.created) != 1 {
+		t.Fatalf("expected the project to be created when the check is skipped")
+	}
+}
+
+func TestCheckRenameConflict(t *testing.T) {
+	fake := &fakeAPI{projects: []*api.Project{{ID: "1", Name: "api", Type: "go"}, {ID: "2", Name: "web", Type: "js"}}}
+	c := &Client{BugsnagAPI: fake}
+	ctx := context.Background()
+
+	if err := checkRenameConflict(ctx, c, "2", "api"); err == nil {
+		t.Fatalf("expected an error for a rename to the name of another project")
+	}
+	if err := checkRenameConflict(ctx, c, "1", "api"); err != nil {
+		t.Fatalf("unexpected error for a project keeping its name: %s", err)
+	}
+	if err := checkRenameConflict(ctx, c, "2", "storefront"); err != nil {
+		t.Fatalf("unexpected error for a free name: %s", err)
+	}
+
+	c.SkipDuplicateNameCheck = true
+	if err := checkRenameConflict(ctx, c, "2", "api"); err != nil {
+		t.Fatalf("expected the check to be skipped, got %s", err)
+	}
+}
+
+func TestResourceProjectCRUD(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"name": "api",
+		"type": "js",
+	})
+
+	if diags := resourceProjectCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	project := server.Project(d.Id())
+	if project == nil || project["type"] != "js" {
+		t.Fatalf("expected a js project to be created, got %v", project)
+	}
+	if d.Get("api_key").(string) != project["api_key"] {
+		t.Fatalf("expected the api_key to be read, got %q", d.Get("api_key"))
+	}
+
+	if diags := resourceProjectRead(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if diags := resourceProjectUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	id := d.Id()
+	if diags := resourceProjectDelete(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if server.Project(id) != nil {
+		t.Fatalf("expected the project to be deleted")
+	}
+
+	d.SetId(id)
+	if diags := resourceProjectDelete(ctx, d, c); diags.HasError() {
+		t.Fatalf("expected deleting a deleted project to succeed, got %v", diags)
+	}
+}
+
+func TestResourceProjectReleaseStages(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	d := testResourceData(t, resourceProject(), nil, map[string]interface{}{
+		"name":           "api",
+		"release_stages": []interface{}{"production", "staging"},
+	}, c)
+
+	if diags := resourceProjectCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if stages := server.Project(d.Id())["release_stages"]; len(stages.([]interface{})) != 2 {
+		t.Fatalf("expected the release stages to be created, got %v", stages)
+	}
+
+	id := d.Id()
+	d = schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"name":           "api",
+		"release_stages": []interface{}{"production", "staging", "development"},
+	})
+	d.SetId(id)
+
+	if diags := resourceProjectUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if stages := server.Project(id)["release_stages"]; len(stages.([]interface{})) != 3 {
+		t.Fatalf("expected the release stages to be updated, got %v", stages)
+	}
+	if stages := d.Get("release_stages").(*schema.Set); stages.Len() != 3 {
+		t.Fatalf("expected the updated release stages to be read back, got %v", stages)
+	}
+
+	// unset, the stages are kept
+	d = testResourceData(t, resourceProject(), d.State(), map[string]interface{}{
+		"name": "api",
+	}, c)
+	if d.HasChange("release_stages") {
+		t.Fatalf("expected unset release stages to be kept")
+	}
+
+	d = testResourceData(t, resourceProject(), d.State(), map[string]interface{}{
+		"name":           "api",
+		"release_stages": []interface{}{},
+	}, c)
+	if diags := resourceProjectUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if stages := server.Project(id)["release_stages"]; len(stages.([]interface{})) != 0 {
+		t.Fatalf("expected the release stages to be removed, got %v", stages)
+	}
+}
+
+func TestResourceProjectCreateWithEmptyLists(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	empty := map[string]interface{}{
+		"release_stages":    []interface{}{},
+		"url_whitelist":     []interface{}{},
+		"global_grouping":   []interface{}{},
+		"location_grouping": []interface{}{},
+	}
+	config := func(extra map[string]interface{}) map[string]interface{} {
+		raw := map[string]interface{}{"name": "web"}
+		for k, v := range empty {
+			raw[k] = v
+		}
+		for k, v := range extra {
+			raw[k] = v
+		}
+		return raw
+	}
+
+	// a new project would otherwise get the production release stage
+	d := testResourceData(t, resourceProject(), nil, config(nil), c)
+	if diags := resourceProjectCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	for key := range empty {
+		if values := server.Project(d.Id())[key]; len(values.([]interface{})) != 0 {
+			t.Fatalf("expected %s to be created empty, got %v", key, values)
+		}
+	}
+
+	existing := server.AddProject(map[string]interface{}{
+		"name":              "worker",
+		"type":              "go",
+		"release_stages":    []interface{}{"production"},
+		"url_whitelist":     []interface{}{"example.com"},
+		"global_grouping":   []interface{}{"ChunkLoadError"},
+		"location_grouping": []interface{}{"vendor/*"},
+	})
+	d = testResourceData(t, resourceProject(), nil, config(map[string]interface{}{
+		"name":           "worker",
+		"adopt_existing": true,
+	}), c)
+	if diags := resourceProjectCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != existing["id"] {
+		t.Fatalf("expected the existing project to be adopted, got %q", d.Id())
+	}
+	for key := range empty {
+		if values := server.Project(d.Id())[key]; len(values.([]interface{})) != 0 {
+			t.Fatalf("expected %s of the adopted project to be emptied, got %v", key, values)
+		}
+	}
+}
+
+func TestResourceProjectGlobalGrouping(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	d := testResourceData(t, resourceProject(), nil, map[string]interface{}{
+		"name":            "web",
+		"global_grouping": []interface{}{"ChunkLoadError", "NetworkError"},
+	}, c)
+
+	if diags := resourceProjectCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if grouping := server.Project(d.Id())["global_grouping"]; len(grouping.([]interface{})) != 2 {
+		t.Fatalf("expected the grouping rules to be sent on create, got %v", grouping)
+	}
+
+	// unset, the grouping rules are kept
+	d = testResourceData(t, resourceProject(), d.State(), map[string]interface{}{
+		"name": "web",
+	}, c)
+	if d.HasChange("global_grouping") {
+		t.Fatalf("expected unset grouping rules to be kept")
+	}
+
+	d = testResourceData(t, resourceProject(), d.State(), map[string]interface{}{
+		"name":            "web",
+		"global_grouping": []interface{}{},
+	}, c)
+	if diags := resourceProjectUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if grouping := server.Project(d.Id())["global_grouping"]; len(grouping.([]interface{})) != 0 {
+		t.Fatalf("expected the grouping rules to be removed, got %v", grouping)
+	}
+}
+
+func TestResourceProjectLocationGrouping(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"name":              "web",
+		"location_grouping": []interface{}{"app/vendor/bundle.js"},
+	})
+
+	if diags := resourceProjectCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	id := d.Id()
+	d = schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"name":              "web",
+		"location_grouping": []interface{}{"app/vendor/bundle.js", "app/vendor/polyfills.js"},
+	})
+	d.SetId(id)
+
+	if diags := resourceProjectUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if grouping := server.Project(id)["location_grouping"]; len(grouping.([]interface{})) != 2 {
+		t.Fatalf("expected the grouping rules to be updated, got %v", grouping)
+	}
+	if grouping := d.Get("location_grouping").(*schema.Set); grouping.Len() != 2 || !grouping.Contains("app/vendor/polyfills.js") {
+		t.Fatalf("expected the updated grouping rules in state, got %v", grouping)
+	}
+
+	d = testResourceData(t, resourceProject(), d.State(), map[string]interface{}{
+		"name":              "web",
+		"location_grouping": []interface{}{},
+	}, c)
+	if diags := resourceProjectUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if grouping := server.Project(id)["location_grouping"]; len(grouping.([]interface{})) != 0 {
+		t.Fatalf("expected the grouping rules to be removed, got %v", grouping)
+	}
+}
+
+func TestResourceProjectResolveOnDeploy(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"name":              "web",
+		"resolve_on_deploy": true,
+	})
+
+	if diags := resourceProjectCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if resolve := server.Project(d.Id())["resolve_on_deploy"]; resolve != true {
+		t.Fatalf("expected resolve_on_deploy to be sent on create, got %v", resolve)
+	}
+
+	// unset, the setting is kept
+	d = testResourceData(t, resourceProject(), d.State(), map[string]interface{}{
+		"name": "web",
+	}, c)
+	if d.HasChange("resolve_on_deploy") {
+		t.Fatalf("expected an unset resolve_on_deploy to be kept")
+	}
+
+	d = testResourceData(t, resourceProject(), d.State(), map[string]interface{}{
+		"name":              "web",
+		"resolve_on_deploy": false,
+	}, c)
+	if !d.HasChange("resolve_on_deploy") {
+		t.Fatalf("expected turning resolve_on_deploy off to be planned")
+	}
+	if diags := resourceProjectUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if resolve := server.Project(d.Id())["resolve_on_deploy"]; resolve != false {
+		t.Fatalf("expected resolve_on_deploy to be turned off, got %v", resolve)
+	}
+}
+
+func TestResourceProjectCollaboratorsCanModifySettings(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"name": "web",
+	})
+
+	if diags := resourceProjectCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if canModify := d.Get("collaborators_can_modify_settings").(bool); !canModify {
+		t.Fatalf("expected the organization's default to be read back when unset")
+	}
+
+	d = testResourceData(t, resourceProject(), d.State(), map[string]interface{}{
+		"name":                              "web",
+		"collaborators_can_modify_settings": false,
+	}, c)
+	if !d.HasChange("collaborators_can_modify_settings") {
+		t.Fatalf("expected stopping collaborators from modifying settings to be planned")
+	}
+	if diags := resourceProjectUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if canModify := server.Project(d.Id())["collaborators_can_modify_settings"]; canModify != false {
+		t.Fatalf("expected collaborators to be stopped from modifying settings, got %v", canModify)
+	}
+}
+
+func TestResourceProjectLanguage(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"name": "web",
+		"type": "rails",
+	})
+
+	if diags := resourceProjectCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if language := d.Get("language"); language != "rails" {
+		t.Fatalf("expected the language to default to the type, got %v", language)
+	}
+
+	id := d.Id()
+	d = schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"name":     "web",
+		"type":     "rails",
+		"language": "ruby",
+	})
+	d.SetId(id)
+
+	if diags := resourceProjectUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if language := server.Project(id)["language"]; language != "ruby" {
+		t.Fatalf("expected the language to be updated, got %v", language)
+	}
+}
+
+func TestResourceProjectTypeForcesReplacement(t *testing.T) {
+	s := resourceProject().Schema["type"]
+	if !s.ForceNew {
+		t.Fatalf("expected changing type to replace the project")
+	}
+	if !s.Computed {
+		t.Fatalf("expected an unset type to keep the existing project")
+	}
+}
+
+func TestProjectAPIKeyIsSensitive(t *testing.T) {
+	if !resourceProject().Schema["api_key"].Sensitive {
+		t.Errorf("expected the resource's api_key to be sensitive")
+	}
+	if !dataSourceProject().Schema["api_key"].Sensitive {
+		t.Errorf("expected the data source's api_key to be sensitive")
+	}
+}
+
+func TestResourceProjectKeyRotation(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	project := server.AddProject(map[string]interface{}{"name": "web", "type": "go"})
+	id := project["id"].(string)
+
+	// only the serial changes
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"key_rotation_serial": 1,
+	})
+	d.SetId(id)
+
+	if diags := resourceProjectUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	rotated := server.Project(id)["api_key"]
+	if rotated == project["api_key"] {
+		t.Fatalf("expected the API key to be regenerated")
+	}
+	if d.Get("api_key") != rotated {
+		t.Fatalf("expected the new API key in state, got %v", d.Get("api_key"))
+	}
+	for _, request := range server.Requests() {
+		if strings.HasPrefix(request, "PATCH ") {
+			t.Fatalf("expected no project update for a key rotation alone, got %s", request)
+		}
+	}
+}
+
+func TestProjectTelemetryOnlyInDataSources(t *testing.T) {
+	for k := range projectTelemetrySchema() {
+		if _, ok := resourceProject().Schema[k]; ok {
+			t.Errorf("expected %s not to be an attribute of bugsnag_project", k)
+		}
+		if _, ok := dataSourceProject().Schema[k]; !ok {
+			t.Errorf("expected %s to be an attribute of the bugsnag_project data source", k)
+		}
+	}
+}
+
+func TestResourceProjectAdoptExisting(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	existing := server.AddProject(map[string]interface{}{"name": "web", "type": "go"})
+
+	d := testResourceData(t, resourceProject(), nil, map[string]interface{}{
+		"name":           "web",
+		"release_stages": []interface{}{"production", "staging"},
+		"adopt_existing": true,
+	}, c)
+
+	if diags := resourceProjectCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != existing["id"] {
+		t.Fatalf("expected the existing project to be adopted, got %q", d.Id())
+	}
+	if stages := server.Project(d.Id())["release_stages"]; len(stages.([]interface{})) != 2 {
+		t.Fatalf("expected the configured settings to be applied, got %v", stages)
+	}
+	for _, request := range server.Requests() {
+		if strings.HasPrefix(request, "POST ") {
+			t.Fatalf("expected no project to be created, got %s", request)
+		}
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"name":           "web",
+		"type":           "rails",
+		"adopt_existing": true,
+	})
+	if diags := resourceProjectCreate(ctx, d, c); !diags.HasError() {
+		t.Fatalf("expected a project of another type not to be adopted")
+	}
+
+	server.AddProject(map[string]interface{}{"name": "web", "type": "go"})
+	d = schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"name":           "web",
+		"adopt_existing": true,
+	})
+	if diags := resourceProjectCreate(ctx, d, c); !diags.HasError() {
+		t.Fatalf("expected an ambiguous name not to be adopted")
+	}
+}
+
+func TestResourceProjectReadRetriesAfterCreate(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	backoff := readAfterCreateBackoff
+	readAfterCreateBackoff = time.Millisecond
+	defer func() { readAfterCreateBackoff = backoff }()
+
+	project := server.AddProject(map[string]interface{}{"name": "web", "type": "go"})
+	projectPath := "/organizations/" + bugsnagtest.OrganizationID + "/projects/" + project["id"].(string)
+
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{})
+	d.SetId(project["id"].(string))
+	d.MarkNewResource()
+
+	server.Fail("GET", projectPath, 404, 404)
+	if diags := resourceProjectRead(ctx, d, c); diags.HasError() {
+		t.Fatalf("expected the read to be retried, got %v", diags)
+	}
+	if d.Get("name") != "web" {
+		t.Fatalf("expected the project to be read, got %v", d.Get("name"))
+	}
+
+	server.Fail("GET", projectPath, 404, 404, 404, 404, 404)
+	if diags := resourceProjectRead(ctx, d, c); !diags.HasError() {
+		t.Fatalf("expected the retries to be bounded")
+	}
+}
+
+func TestResourceProjectEmailNotifications(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	notifications := func(newErrors, dailySummary bool) []interface{} {
+		return []interface{}{
+			map[string]interface{}{
+				"new_errors":      newErrors,
+				"reopened_errors": true,
+				"error_spikes":    true,
+				"daily_summary":   dailySummary,
+			},
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"name":                "web",
+		"email_notifications": notifications(false, true),
+	})
+
+	if diags := resourceProjectCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if settings := server.Setting(d.Id(), "email_notifications"); settings["new_errors"] != false || settings["daily_summary"] != true {
+		t.Fatalf("expected the settings to be applied on create, got %v", settings)
+	}
+
+	id := d.Id()
+	d = schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"email_notifications": notifications(true, false),
+	})
+	d.SetId(id)
+
+	if diags := resourceProjectUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if settings := server.Setting(id, "email_notifications"); settings["new_errors"] != true || settings["daily_summary"] != false {
+		t.Fatalf("expected the settings to be updated, got %v", settings)
+	}
+	for _, request := range server.Requests() {
+		if request == "PATCH /organizations/"+bugsnagtest.OrganizationID+"/projects/"+id {
+			t.Fatalf("expected no project update for a change of email notifications alone")
+		}
+	}
+}
+
+func TestResourceProjectReadsEmailNotifications(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+
+	project := server.AddProject(map[string]interface{}{"name": "web", "type": "go"})
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{})
+	d.SetId(project["id"].(string))
+
+	if diags := resourceProjectRead(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	blocks := d.Get("email_notifications").([]interface{})
+	if len(blocks) != 1 || blocks[0].(map[string]interface{})["new_errors"] != true {
+		t.Fatalf("expected the project's settings in state, got %v", blocks)
+	}
+}
+
+func TestResourceProjectReopenRules(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"name": "web",
+		"reopen_rules": []interface{}{
+			map[string]interface{}{"enabled": true, "occurrences": 5, "hours": 1},
+		},
+	})
+
+	if diags := resourceProjectCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if rules := server.Setting(d.Id(), "reopen_rules"); rules["enabled"] != true || rules["occurrences"] != float64(5) {
+		t.Fatalf("expected the rules to be applied on create, got %v", rules)
+	}
+
+	id := d.Id()
+	d = schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"reopen_rules": []interface{}{
+			map[string]interface{}{"enabled": false, "occurrences": 5, "hours": 1},
+		},
+	})
+	d.SetId(id)
+
+	if diags := resourceProjectUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if rules := server.Setting(id, "reopen_rules"); rules["enabled"] != false {
+		t.Fatalf("expected the rules to be disabled, got %v", rules)
+	}
+	blocks := d.Get("reopen_rules").([]interface{})
+	if len(blocks) != 1 || blocks[0].(map[string]interface{})["hours"] != 1 {
+		t.Fatalf("expected the rules in state, got %v", blocks)
+	}
+}
+
+func TestResourceProjectSpikeDetection(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	spikeDetection := func(multiplier float64) []interface{} {
+		return []interface{}{
+			map[string]interface{}{
+				"enabled":              true,
+				"threshold_multiplier": multiplier,
+				"minimum_events":       50,
+				"window_minutes":       15,
+				"notify_integrations":  false,
 			},
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"name":            "web",
+		"spike_detection": spikeDetection(2.5),
+	})
+
+	if diags := resourceProjectCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	settings := server.Setting(d.Id(), "spike_detection")
+	if settings["threshold_multiplier"] != 2.5 || settings["minimum_events"] != float64(50) || settings["notify_integrations"] != false {
+		t.Fatalf("expected the settings to be applied on create, got %v", settings)
+	}
+
+	id := d.Id()
+	d = schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"spike_detection": spikeDetection(8),
+	})
+	d.SetId(id)
+
+	if diags := resourceProjectUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if settings := server.Setting(id, "spike_detection"); settings["threshold_multiplier"] != float64(8) {
+		t.Fatalf("expected the settings to be updated, got %v", settings)
+	}
+}
+
+func TestResourceProjectCreateWithSettings(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	d := testResourceData(t, resourceProject(), nil, map[string]interface{}{
+		"name": "web",
+		"spike_detection": []interface{}{
+			map[string]interface{}{"threshold_multiplier": 2.5},
 		},
+		"critical_stability": 95.0,
+	}, c)
+
+	if diags := resourceProjectCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	for _, request := range server.Requests() {
+		if strings.HasPrefix(request, "PATCH ") {
+			t.Fatalf("expected the settings to be created along with the project, got %s", request)
+		}
+	}
+	if settings := server.Setting(d.Id(), "spike_detection"); settings["threshold_multiplier"] != 2.5 {
+		t.Fatalf("expected the spike detection to be applied, got %v", settings)
+	}
+	if targets := server.Setting(d.Id(), "stability_targets"); targets["critical_stability"] != 95.0 || targets["target_stability"] != 99.5 {
+		t.Fatalf("expected the critical stability alone to be applied, got %v", targets)
+	}
+}
+
+func TestResourceProjectStabilityTargets(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	d := testResourceData(t, resourceProject(), nil, map[string]interface{}{
+		"name":             "web",
+		"target_stability": 99.9,
+	}, c)
+
+	if diags := resourceProjectCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	id := d.Id()
+	targets := server.Setting(id, "stability_targets")
+	if targets["target_stability"] != 99.9 || targets["critical_stability"] != 98.0 {
+		t.Fatalf("expected the target alone to be applied on create, got %v", targets)
+	}
+	if critical := d.Get("critical_stability").(float64); critical != 98.0 {
+		t.Fatalf("expected the critical stability to be read, got %v", critical)
+	}
+
+	d = testResourceData(t, resourceProject(), d.State(), map[string]interface{}{
+		"name":               "web",
+		"critical_stability": 95.0,
+	}, c)
+
+	if diags := resourceProjectUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if targets := server.Setting(id, "stability_targets"); targets["target_stability"] != 99.9 || targets["critical_stability"] != 95.0 {
+		t.Fatalf("expected the critical stability alone to be updated, got %v", targets)
+	}
+
+	// 0 is a target like any other
+	d = testResourceData(t, resourceProject(), d.State(), map[string]interface{}{
+		"name":               "web",
+		"critical_stability": 0.0,
+	}, c)
+
+	if diags := resourceProjectUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if targets := server.Setting(id, "stability_targets"); targets["critical_stability"] != 0.0 {
+		t.Fatalf("expected the critical stability to be set to 0, got %v", targets)
+	}
+
+	// both targets are read with a single request
+	requests := len(server.Requests())
+	if diags := resourceProjectRead(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	reads := 0
+	for _, request := range server.Requests()[requests:] {
+		if strings.HasPrefix(request, "GET ") && strings.HasSuffix(request, "/projects/"+id+"/stability_targets") {
+			reads++
+		}
+	}
+	if reads != 1 {
+		t.Fatalf("expected the stability targets to be read once, got %d requests", reads)
+	}
+	if d.Get("target_stability").(float64) != 99.9 || d.Get("critical_stability").(float64) != 0 {
+		t.Fatalf("expected both targets to be read, got %v and %v", d.Get("target_stability"), d.Get("critical_stability"))
+	}
+}
+
+func TestResourceProjectCreateWithZeroStabilityTarget(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+
+	d := testResourceData(t, resourceProject(), nil, map[string]interface{}{
+		"name":               "web",
+		"critical_stability": 0.0,
+	}, c)
+
+	if diags := resourceProjectCreate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if targets := server.Setting(d.Id(), "stability_targets"); targets["critical_stability"] != 0.0 {
+		t.Fatalf("expected the critical stability to be created as 0, got %v", targets)
+	}
+}
+
+func TestResourceProjectStability(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	project := server.AddProject(map[string]interface{}{"name": "web"})
+	server.SetStability(project["id"].(string), 97.5, 99.25)
+
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{})
+	d.SetId(project["id"].(string))
+
+	if diags := resourceProjectRead(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if user, session := d.Get("user_stability").(float64), d.Get("session_stability").(float64); user != 97.5 || session != 99.25 {
+		t.Fatalf("expected the stability to be read, got %v and %v", user, session)
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"fetch_statistics": false,
 	})
+	d.SetId(project["id"].(string))
+
+	requests := len(server.Requests())
+	if diags := resourceProjectRead(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	for _, request := range server.Requests()[requests:] {
+		if strings.HasSuffix(request, "/stability") {
+			t.Fatalf("expected the stability not to be read without fetch_statistics, got %s", request)
+		}
+	}
 }
 
-const testAccResourceBugsnag = `
-resource "bugsnag_resource" "foo" {
-  sample_attribute = "bar"
+func TestResourceProjectEventUsage(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	project := server.AddProject(map[string]interface{}{"name": "web"})
+
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{})
+	d.SetId(project["id"].(string))
+
+	if diags := resourceProjectRead(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if _, ok := d.GetOk("event_quota"); ok {
+		t.Fatalf("expected no quota for a project without an allocation")
+	}
+
+	server.SetEventUsage(project["id"].(string), 2500, 10000)
+	if diags := resourceProjectRead(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if used, quota := d.Get("events_used").(int), d.Get("event_quota").(int); used != 2500 || quota != 10000 {
+		t.Fatalf("expected the event usage to be read, got %v of %v", used, quota)
+	}
+	if percent := d.Get("event_quota_used_percent").(float64); percent != 25 {
+		t.Fatalf("expected a quarter of the quota to be used, got %v", percent)
+	}
+}
+
+func TestResourceProjectCustomEventFields(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	d := testResourceData(t, resourceProject(), nil, map[string]interface{}{
+		"name": "web",
+		"custom_event_field": []interface{}{
+			map[string]interface{}{"display_id": "tenant_id", "path": "metaData.tenant.id", "name": "Tenant", "pivot": true},
+			map[string]interface{}{"display_id": "build_variant", "path": "metaData.app.variant", "name": "Build variant", "pivot": false},
+		},
+	}, c)
+
+	if diags := resourceProjectCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	id := d.Id()
+	if fields := server.EventFields(id); len(fields) != 2 {
+		t.Fatalf("expected the fields to be created, got %v", fields)
+	}
+	if fields := d.Get("custom_event_field").([]interface{}); len(fields) != 2 {
+		t.Fatalf("expected only the custom fields in state, got %v", fields)
+	}
+
+	d = testResourceData(t, resourceProject(), d.State(), map[string]interface{}{
+		"name": "web",
+		"custom_event_field": []interface{}{
+			map[string]interface{}{"display_id": "tenant_id", "path": "metaData.tenant.id", "name": "Tenant ID", "pivot": false},
+		},
+	}, c)
+
+	if diags := resourceProjectUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	fields := server.EventFields(id)
+	if len(fields) != 1 || fields[0]["display_id"] != "tenant_id" {
+		t.Fatalf("expected the undeclared field to be deleted, got %v", fields)
+	}
+	if fields[0]["filter_options"].(map[string]interface{})["name"] != "Tenant ID" || fields[0]["pivot_options"] != nil {
+		t.Fatalf("expected the field to be updated, got %v", fields[0])
+	}
+
+	// removing the last block deletes the last field
+	d = testResourceData(t, resourceProject(), d.State(), map[string]interface{}{
+		"name": "web",
+	}, c)
+	if !d.HasChange("custom_event_field") {
+		t.Fatalf("expected removing the last custom_event_field to be planned")
+	}
+	if diags := resourceProjectUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if fields := server.EventFields(id); len(fields) != 0 {
+		t.Fatalf("expected the last field to be deleted, got %v", fields)
+	}
+
+	d = testResourceData(t, resourceProject(), d.State(), map[string]interface{}{
+		"name": "web",
+	}, c)
+	if d.HasChange("custom_event_field") {
+		t.Fatalf("expected no change once the fields are deleted, got %v", d.Get("custom_event_field"))
+	}
+}
+
+func TestResourceProjectUndeclaredCustomEventFields(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	d := testResourceData(t, resourceProject(), nil, map[string]interface{}{
+		"name": "web",
+	}, c)
+	if diags := resourceProjectCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if _, err := c.CreateEventField(ctx, d.Id(), &api.EventField{Custom: true, DisplayID: "tenant_id", Path: "metaData.tenant.id"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// the fields of a project that declares none are left alone
+	if diags := resourceProjectRead(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	d = testResourceData(t, resourceProject(), d.State(), map[string]interface{}{
+		"name": "web",
+	}, c)
+	if d.HasChange("custom_event_field") {
+		t.Fatalf("expected the fields of the project to be kept, got %v", d.Get("custom_event_field"))
+	}
+	if diags := resourceProjectUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if fields := server.EventFields(d.Id()); len(fields) != 1 {
+		t.Fatalf("expected the undeclared field to be kept, got %v", fields)
+	}
+}
+
+func TestResourceProjectCollaborators(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	member := server.AddCollaborator("dev@example.com")
+
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"name":          "web",
+		"collaborators": []interface{}{member["id"], "new@example.com"},
+	})
+	d.MarkNewResource()
+
+	if diags := resourceProjectCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	collaborators := server.Collaborators()
+	if len(collaborators) != 2 {
+		t.Fatalf("expected the new collaborator to be invited, got %v", collaborators)
+	}
+	for _, collaborator := range collaborators {
+		if ids := collaborator["project_ids"].([]interface{}); len(ids) != 1 || ids[0] != d.Id() {
+			t.Errorf("expected %s to be given access to the project, got %v", collaborator["email"], ids)
+		}
+	}
+	if list := d.Get("collaborators").([]interface{}); len(list) != 2 {
+		t.Fatalf("expected the collaborators to be kept as configured, got %v", list)
+	}
+}
+
+func TestResourceProjectURLWhitelist(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	d := testResourceData(t, resourceProject(), nil, map[string]interface{}{
+		"name":          "web",
+		"url_whitelist": []interface{}{"Example.com/", "*.example.com"},
+	}, c)
+
+	if diags := resourceProjectCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if whitelist := d.Get("url_whitelist").(*schema.Set); whitelist.Len() != 2 || !whitelist.Contains("Example.com/") {
+		t.Fatalf("expected the normalized whitelist to be read back, got %v", whitelist.List())
+	}
+
+	// unset, the whitelist is kept
+	d = testResourceData(t, resourceProject(), d.State(), map[string]interface{}{
+		"name": "web",
+	}, c)
+	if d.HasChange("url_whitelist") {
+		t.Fatalf("expected an unset whitelist to be kept")
+	}
+
+	d = testResourceData(t, resourceProject(), d.State(), map[string]interface{}{
+		"name":          "web",
+		"url_whitelist": []interface{}{},
+	}, c)
+	if diags := resourceProjectUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if whitelist := server.Project(d.Id())["url_whitelist"]; len(whitelist.([]interface{})) != 0 {
+		t.Fatalf("expected the whitelist to be emptied, got %v", whitelist)
+	}
+}
+
+func TestHashURLWhitelistEntry(t *testing.T) {
+	cases := []struct {
+		a, b string
+		same bool
+	}{
+		{"example.com", "Example.com/", true},
+		{"*.example.com", " *.example.com ", true},
+		{"example.com", "example.org", false},
+	}
+
+	for _, tc := range cases {
+		if got := hashURLWhitelistEntry(tc.a) == hashURLWhitelistEntry(tc.b); got != tc.same {
+			t.Errorf("%q and %q: expected the same element %v, got %v", tc.a, tc.b, tc.same, got)
+		}
+	}
+}
+
+func TestResourceProjectSetArgumentsIgnoreOrder(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	project := server.AddProject(map[string]interface{}{
+		"name":           "web",
+		"release_stages": []interface{}{"staging", "production"},
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"release_stages": []interface{}{"production", "staging"},
+	})
+	d.SetId(project["id"].(string))
+
+	if diags := resourceProjectRead(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	stages := expandStringSet(d.Get("release_stages").(*schema.Set))
+	slices.Sort(stages)
+	if !slices.Equal(stages, []string{"production", "staging"}) {
+		t.Fatalf("expected the stages read in another order to equal the configured ones, got %v", stages)
+	}
+}
+
+func TestResourceProjectImport(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	project := server.AddProject(map[string]interface{}{"name": "api", "type": "rails"})
+
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{})
+	d.SetId(project["id"].(string))
+
+	if diags := resourceProjectRead(context.Background(), d, newTestClient(server)); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Get("name").(string) != "api" || d.Get("type").(string) != "rails" || d.Get("api_key").(string) != project["api_key"] {
+		t.Fatalf("expected the imported project to be read from its ID")
+	}
+}
+
+func TestResourceProjectAPIFailures(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+	projectsPath := "/organizations/" + bugsnagtest.OrganizationID + "/projects"
+
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"name": "api",
+	})
+
+	server.Fail("GET", projectsPath, 429, 429)
+	if diags := resourceProjectCreate(ctx, d, c); !diags.HasError() || !regexp.MustCompile("rate limit").MatchString(diags[0].Detail) {
+		t.Fatalf("expected a rate limit error, got %v", diags)
+	}
+
+	server.Fail("POST", projectsPath, 500)
+	if diags := resourceProjectCreate(ctx, d, c); !diags.HasError() {
+		t.Fatalf("expected an error for a failed create")
+	}
+
+	server.Fail("GET", projectsPath+"/missing", 500)
+	d.SetId("missing")
+	if diags := resourceProjectRead(ctx, d, c); !diags.HasError() {
+		t.Fatalf("expected an error for a failed read")
+	}
+}
+
+func TestResourceProjectPartialUpdate(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	project := server.AddProject(map[string]interface{}{"name": "web", "type": "go"})
+	id := project["id"].(string)
+
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+		"name": "storefront",
+		"spike_detection": []interface{}{
+			map[string]interface{}{"threshold_multiplier": 2.5},
+		},
+	})
+	d.SetId(id)
+
+	server.Fail("PATCH", "/organizations/"+bugsnagtest.OrganizationID+"/projects/"+id+"/spike_detection", 400)
+	if diags := resourceProjectUpdate(ctx, d, c); !diags.HasError() {
+		t.Fatalf("expected an error for the failed spike detection update")
+	}
+
+	if name := server.Project(id)["name"]; name != "storefront" {
+		t.Fatalf("expected the project to be renamed despite the failure, got %v", name)
+	}
+	if name := d.Get("name").(string); name != "storefront" {
+		t.Errorf("expected the applied rename to be kept, got %q", name)
+	}
+	if blocks := d.Get("spike_detection").([]interface{}); len(blocks) != 0 {
+		t.Errorf("expected the failed spike detection update to be restored for a retry, got %v", blocks)
+	}
+}
+
+func TestResourceProjectReadRemoteRename(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	project := server.AddProject(map[string]interface{}{"name": "web", "type": "go"})
+	renamed := "storefront"
+	if _, err := c.UpdateProject(ctx, project["id"].(string), &api.UpdateProjectRequest{Name: &renamed}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, ignore := range []bool{false, true} {
+		d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{
+			"name":                  "web",
+			"ignore_remote_renames": ignore,
+		})
+		d.SetId(project["id"].(string))
+
+		diags := resourceProjectRead(ctx, d, c)
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Detail, `"storefront"`) {
+			t.Fatalf("expected a warning about the rename, got %v", diags)
+		}
+
+		want := "storefront"
+		if ignore {
+			want = "web"
+		}
+		if name := d.Get("name").(string); name != want {
+			t.Errorf("expected name %q with ignore_remote_renames %t, got %q", want, ignore, name)
+		}
+	}
+}
+
+func TestResourceProjectReadRemovesDeletedProject(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	project := server.AddProject(map[string]interface{}{"name": "web", "type": "go"})
+	server.DeleteProject(project["id"].(string))
+
+	d := schema.TestResourceDataRaw(t, resourceProject().Schema, map[string]interface{}{})
+	d.SetId(project["id"].(string))
+
+	if diags := resourceProjectRead(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected the deleted project to be removed from state")
+	}
+
+	d.SetId(project["id"].(string))
+	d.MarkNewResource()
+	if diags := resourceProjectRead(ctx, d, c); !diags.HasError() {
+		t.Fatalf("expected an error when a just created project is missing")
+	}
 }
-`
@@ -0,0 +1,182 @@
+package bugsnag
+
+import (
+	"context"
+	"errors"
+	"log"
+	"regexp"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
+)
+
+// resourceCollaborator manages a member of the organization: it invites them
+// on creation, and removes them from the organization on destroy. Unlike the
+// collaborators argument of bugsnag_project, project_ids is authoritative.
+func resourceCollaborator() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a collaborator of the Bugsnag organization and the projects they can access. " +
+			"Creating it invites the collaborator to the organization; destroying it removes them from the organization.",
+		CreateContext: resourceCollaboratorCreate,
+		ReadContext:   resourceCollaboratorRead,
+		UpdateContext: resourceCollaboratorUpdate,
+		DeleteContext: resourceCollaboratorDelete,
+		Importer: &schema.ResourceImporter{
+			// the ID is the collaborator ID
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"email": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateEmail,
+				Description:  "The email of the collaborator, to which the invitation is sent. Changing it invites someone else, and removes the collaborator.",
+			},
+			"admin": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the collaborator is an admin of the organization, with access to every project.",
+			},
+			"project_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				Description: "The IDs of the projects the collaborator can access. Access granted otherwise, such as with the `collaborators` argument of `bugsnag_project`, is revoked on the next apply.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the collaborator, once they have accepted the invitation.",
+			},
+			"pending_invitation": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the collaborator has yet to accept the invitation to the organization.",
+			},
+			"request_options": requestOptionsSchema(),
+		},
+	}
+}
+
+// validateEmail accepts anything that looks like an email address, which
+// Bugsnag validates further when sending the invitation.
+var validateEmail = validation.StringMatch(regexp.MustCompile(`^[^@\s]+@[^@\s]+$`), "must be an email address")
+
+func resourceCollaboratorCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutCreate)
+
+	admin := d.Get("admin").(bool)
+	projectIDs := expandStringSet(d.Get("project_ids").(*schema.Set))
+
+	collaborator, err := c.InviteCollaborator(ctx, &api.InviteCollaboratorRequest{
+		Email:      d.Get("email").(string),
+		Admin:      admin,
+		ProjectIDs: projectIDs,
+	})
+	if err != nil {
+		return apiErrorDiagnostics("Unable to invite the Bugsnag collaborator", err)
+	}
+
+	d.SetId(collaborator.ID)
+
+	// inviting a member of the organization keeps the access they had, which
+	// project_ids then revokes
+	if collaborator.IsAdmin != admin || !sameStrings(collaborator.ProjectIDs, projectIDs) {
+		if _, err := c.UpdateCollaborator(ctx, d.Id(), &api.UpdateCollaboratorRequest{Admin: &admin, ProjectIDs: &projectIDs}); err != nil {
+			return apiErrorDiagnostics("Unable to update the Bugsnag collaborator", err)
+		}
+	}
+
+	return resourceCollaboratorRead(ctx, d, m)
+}
+
+func resourceCollaboratorRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutRead)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	collaborator, err := c.GetCollaborator(ctx, d.Id())
+	if errors.Is(err, api.ErrNotFound) && !d.IsNewResource() {
+		log.Printf("[WARN] Bugsnag collaborator %s not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return apiErrorDiagnostics("Unable to read the Bugsnag collaborator", err)
+	}
+
+	attributes := map[string]interface{}{
+		"email":              collaborator.Email,
+		"admin":              collaborator.IsAdmin,
+		"project_ids":        collaborator.ProjectIDs,
+		"name":               collaborator.Name,
+		"pending_invitation": collaborator.PendingInvitation,
+	}
+	for k, v := range attributes {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return append(diags, rateLimitDiagnostics(c)...)
+}
+
+func resourceCollaboratorUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutUpdate)
+
+	request := &api.UpdateCollaboratorRequest{}
+	if d.HasChange("admin") {
+		admin := d.Get("admin").(bool)
+		request.Admin = &admin
+	}
+	if d.HasChange("project_ids") {
+		projectIDs := expandStringSet(d.Get("project_ids").(*schema.Set))
+		request.ProjectIDs = &projectIDs
+	}
+
+	if request.Admin != nil || request.ProjectIDs != nil {
+		if _, err := c.UpdateCollaborator(ctx, d.Id(), request); err != nil {
+			return apiErrorDiagnostics("Unable to update the Bugsnag collaborator", err)
+		}
+	}
+
+	return resourceCollaboratorRead(ctx, d, m)
+}
+
+func resourceCollaboratorDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutDelete)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	// collaborators who already left the organization need no removing
+	if err := c.DeleteCollaborator(ctx, d.Id()); err != nil && !errors.Is(err, api.ErrNotFound) {
+		return apiErrorDiagnostics("Unable to remove the Bugsnag collaborator", err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// sameStrings reports whether a and b hold the same strings, in any order.
+func sameStrings(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
@@ -0,0 +1,199 @@
+package bugsnag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
+)
+
+// resourceCollaboratorInvitation manages an invitation to the organization
+// rather than the collaborator it makes: destroying it revokes the
+// invitation if it is still pending, and leaves the collaborator alone once
+// they have accepted it.
+func resourceCollaboratorInvitation() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages an invitation to the Bugsnag organization, tracking whether it has been accepted. " +
+			"Destroying it revokes the invitation if it is still pending; a collaborator who has accepted it is left alone.",
+		CreateContext: resourceCollaboratorInvitationCreate,
+		ReadContext:   resourceCollaboratorInvitationRead,
+		UpdateContext: resourceCollaboratorInvitationUpdate,
+		DeleteContext: resourceCollaboratorInvitationDelete,
+		Importer: &schema.ResourceImporter{
+			// the ID is the ID of the invited collaborator
+			StateContext: resourceCollaboratorInvitationImport,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"email": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateEmail,
+				Description:  "The email to send the invitation to.",
+			},
+			"admin": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Whether to invite the collaborator as an admin of the organization, with access to every project.",
+			},
+			"project_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				Description: "The IDs of the projects to give the collaborator access to.",
+			},
+			"resend_serial": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Change this value to send the invitation again, if it is still pending.",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the invitation: `pending` until the collaborator accepts it, then `accepted`.",
+			},
+			"request_options": requestOptionsSchema(),
+		},
+	}
+}
+
+const (
+	invitationPending  = "pending"
+	invitationAccepted = "accepted"
+)
+
+func resourceCollaboratorInvitationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutCreate)
+
+	collaborator, err := c.InviteCollaborator(ctx, &api.InviteCollaboratorRequest{
+		Email:      d.Get("email").(string),
+		Admin:      d.Get("admin").(bool),
+		ProjectIDs: expandStringSet(d.Get("project_ids").(*schema.Set)),
+	})
+	if err != nil {
+		return apiErrorDiagnostics("Unable to invite the Bugsnag collaborator", err)
+	}
+
+	d.SetId(collaborator.ID)
+
+	return resourceCollaboratorInvitationRead(ctx, d, m)
+}
+
+func resourceCollaboratorInvitationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutRead)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	// an invitation revoked outside of Terraform, or a collaborator who left
+	// the organization, is gone
+	collaborator, err := c.GetCollaborator(ctx, d.Id())
+	if errors.Is(err, api.ErrNotFound) && !d.IsNewResource() {
+		log.Printf("[WARN] Bugsnag collaborator %s not found, removing the invitation from state", d.Id())
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return apiErrorDiagnostics("Unable to read the Bugsnag collaborator invitation", err)
+	}
+
+	// the permissions are those invited with, which the collaborator may
+	// since have been given others of; only the state is read back
+	state := invitationAccepted
+	if collaborator.PendingInvitation {
+		state = invitationPending
+	}
+	if err := d.Set("email", collaborator.Email); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("state", state); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return append(diags, rateLimitDiagnostics(c)...)
+}
+
+func resourceCollaboratorInvitationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutUpdate)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	if d.HasChange("resend_serial") {
+		if d.Get("state").(string) == invitationAccepted {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "invitation already accepted",
+				Detail:   "the invitation was not sent again, since the collaborator has already accepted it.",
+			})
+		} else if err := c.ResendCollaboratorInvitation(ctx, d.Id()); err != nil {
+			return apiErrorDiagnostics("Unable to resend the Bugsnag collaborator invitation", err)
+		}
+	}
+
+	return append(diags, resourceCollaboratorInvitationRead(ctx, d, m)...)
+}
+
+func resourceCollaboratorInvitationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutDelete)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	collaborator, err := c.GetCollaborator(ctx, d.Id())
+	if err != nil && !errors.Is(err, api.ErrNotFound) {
+		return apiErrorDiagnostics("Unable to read the Bugsnag collaborator invitation", err)
+	}
+
+	// a collaborator who accepted the invitation is a member of the
+	// organization, which destroying the invitation doesn't undo
+	if collaborator != nil && collaborator.PendingInvitation {
+		if err := c.DeleteCollaborator(ctx, d.Id()); err != nil && !errors.Is(err, api.ErrNotFound) {
+			return apiErrorDiagnostics("Unable to revoke the Bugsnag collaborator invitation", err)
+		}
+	} else if collaborator != nil {
+		log.Printf("[INFO] leaving the Bugsnag collaborator %s, who accepted the invitation, in the organization", d.Id())
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// resourceCollaboratorInvitationImport takes the permissions the collaborator
+// has for those they were invited with, which Read leaves alone, so that an
+// imported invitation isn't replaced, and so sent again, by the next plan.
+func resourceCollaboratorInvitationImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutRead)
+
+	collaborator, err := c.GetCollaborator(ctx, d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("reading the Bugsnag collaborator %s: %w", d.Id(), err)
+	}
+
+	if err := d.Set("admin", collaborator.IsAdmin); err != nil {
+		return nil, err
+	}
+	if err := d.Set("project_ids", collaborator.ProjectIDs); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,149 @@
+package bugsnag
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-bugsnag/internal/bugsnagtest"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
+)
+
+func TestResourceCollaboratorInvitationCRUD(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	d := schema.TestResourceDataRaw(t, resourceCollaboratorInvitation().Schema, map[string]interface{}{
+		"email": "dev@example.com",
+	})
+	d.MarkNewResource()
+
+	if diags := resourceCollaboratorInvitationCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() == "" || d.Get("state") != invitationPending {
+		t.Fatalf("expected a pending invitation, got %q", d.Get("state"))
+	}
+
+	id := d.Id()
+	d = schema.TestResourceDataRaw(t, resourceCollaboratorInvitation().Schema, map[string]interface{}{
+		"email":         "dev@example.com",
+		"resend_serial": 1,
+	})
+	d.SetId(id)
+	if err := d.Set("state", invitationPending); err != nil {
+		t.Fatal(err)
+	}
+
+	if diags := resourceCollaboratorInvitationUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	resent := slices.ContainsFunc(server.Requests(), func(request string) bool {
+		return strings.HasPrefix(request, "POST ") && strings.HasSuffix(request, "/collaborators/"+id+"/resend_invitation")
+	})
+	if !resent {
+		t.Fatalf("expected the invitation to be sent again, got %v", server.Requests())
+	}
+
+	// a pending invitation is revoked
+	if diags := resourceCollaboratorInvitationDelete(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if len(server.Collaborators()) != 0 {
+		t.Fatalf("expected the pending invitation to be revoked")
+	}
+
+	d.SetId(id)
+	if diags := resourceCollaboratorInvitationRead(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected a revoked invitation to be removed from state")
+	}
+}
+
+func TestResourceCollaboratorInvitationAccepted(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	d := schema.TestResourceDataRaw(t, resourceCollaboratorInvitation().Schema, map[string]interface{}{
+		"email":         "dev@example.com",
+		"resend_serial": 1,
+	})
+	d.MarkNewResource()
+
+	if diags := resourceCollaboratorInvitationCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	server.AcceptInvitation(d.Id(), "Dev")
+	if diags := resourceCollaboratorInvitationRead(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Get("state") != invitationAccepted {
+		t.Fatalf("expected the invitation to be accepted, got %q", d.Get("state"))
+	}
+
+	// an accepted invitation isn't sent again
+	diags := resourceCollaboratorInvitationUpdate(ctx, d, c)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if len(diags) == 0 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a warning about the accepted invitation, got %v", diags)
+	}
+
+	// the collaborator stays in the organization
+	if diags := resourceCollaboratorInvitationDelete(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if len(server.Collaborators()) != 1 {
+		t.Fatalf("expected the collaborator who accepted the invitation to be left alone")
+	}
+}
+
+func TestResourceCollaboratorInvitationImport(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	project := server.AddProject(map[string]interface{}{"name": "checkout"})["id"].(string)
+	collaborator, err := c.InviteCollaborator(ctx, &api.InviteCollaboratorRequest{
+		Email:      "dev@example.com",
+		Admin:      true,
+		ProjectIDs: []string{project},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	d := resourceCollaboratorInvitation().TestResourceData()
+	d.SetId(collaborator.ID)
+	imported, err := resourceCollaboratorInvitationImport(ctx, d, c)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	d = imported[0]
+	if diags := resourceCollaboratorInvitationRead(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	// the permissions are ForceNew: a change would revoke the invitation
+	// and send it again
+	d = testResourceData(t, resourceCollaboratorInvitation(), d.State(), map[string]interface{}{
+		"email":       "dev@example.com",
+		"admin":       true,
+		"project_ids": []interface{}{project},
+	}, c)
+	if d.HasChanges("admin", "project_ids") {
+		t.Fatalf("expected the imported invitation not to be replaced, got admin %v and project_ids %v", d.Get("admin"), d.Get("project_ids").(*schema.Set).List())
+	}
+}
@@ -0,0 +1,99 @@
+package bugsnag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-bugsnag/internal/bugsnagtest"
+)
+
+func TestResourceCollaboratorCRUD(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	web := server.AddProject(map[string]interface{}{"name": "web"})["id"].(string)
+	api := server.AddProject(map[string]interface{}{"name": "api"})["id"].(string)
+
+	d := schema.TestResourceDataRaw(t, resourceCollaborator().Schema, map[string]interface{}{
+		"email":       "dev@example.com",
+		"project_ids": []interface{}{web},
+	})
+	d.MarkNewResource()
+
+	if diags := resourceCollaboratorCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() == "" || !d.Get("pending_invitation").(bool) {
+		t.Fatalf("expected the collaborator to be invited")
+	}
+	if projectIDs := d.Get("project_ids").(*schema.Set); projectIDs.Len() != 1 || !projectIDs.Contains(web) {
+		t.Fatalf("expected access to the configured project, got %v", projectIDs.List())
+	}
+
+	id := d.Id()
+	d = schema.TestResourceDataRaw(t, resourceCollaborator().Schema, map[string]interface{}{
+		"email":       "dev@example.com",
+		"admin":       true,
+		"project_ids": []interface{}{api},
+	})
+	d.SetId(id)
+
+	if diags := resourceCollaboratorUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	collaborator := server.Collaborators()[0]
+	if collaborator["is_admin"] != true {
+		t.Fatalf("expected the collaborator to be made an admin")
+	}
+	if projectIDs := collaborator["project_ids"].([]interface{}); len(projectIDs) != 1 || projectIDs[0] != api {
+		t.Fatalf("expected the project access to be replaced, got %v", projectIDs)
+	}
+
+	if diags := resourceCollaboratorDelete(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if len(server.Collaborators()) != 0 {
+		t.Fatalf("expected the collaborator to be removed")
+	}
+
+	d.SetId(id)
+	if diags := resourceCollaboratorRead(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected a removed collaborator to be removed from state")
+	}
+}
+
+func TestResourceCollaboratorCreateExistingMember(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	web := server.AddProject(map[string]interface{}{"name": "web"})["id"].(string)
+	api := server.AddProject(map[string]interface{}{"name": "api"})["id"].(string)
+	member := server.AddCollaborator("ops@example.com")
+	if err := c.AddCollaboratorProjects(ctx, member["id"].(string), []string{api}); err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceCollaborator().Schema, map[string]interface{}{
+		"email":       "ops@example.com",
+		"project_ids": []interface{}{web},
+	})
+	d.MarkNewResource()
+
+	if diags := resourceCollaboratorCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != member["id"] {
+		t.Fatalf("expected the existing member to be managed, got %q", d.Id())
+	}
+	if projectIDs := d.Get("project_ids").(*schema.Set); projectIDs.Len() != 1 || !projectIDs.Contains(web) {
+		t.Fatalf("expected the access the member had to be replaced, got %v", projectIDs.List())
+	}
+}
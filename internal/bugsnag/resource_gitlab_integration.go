@@ -0,0 +1,168 @@
+package bugsnag
+
+import (
+	"context"
+	"errors"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
+)
+
+// resourceGitLabIntegration creates GitLab issues from the errors of a
+// project. A project has at most one, so creating it over an existing
+// integration takes it over. Its access token is write-only; see
+// writeOnlySecretSchema.
+func resourceGitLabIntegration() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"project_id": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "The ID of the Bugsnag project.",
+		},
+		"instance_url": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "https://gitlab.com",
+			ValidateFunc: validation.IsURLWithHTTPS,
+			Description:  "The URL of the GitLab instance, for self-hosted GitLab.",
+		},
+		"project_path": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringMatch(gitLabProjectPathPattern, "must be the path of a GitLab project, e.g. group/project"),
+			Description:  "The path of the GitLab project issues are created in, e.g. `group/subgroup/project`.",
+		},
+		"request_options": requestOptionsSchema(),
+	}
+	for k, v := range writeOnlySecretSchema("token", "The GitLab access token issues are created with, with the `api` scope. It is required to create the integration.") {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		Description:   "Manages the GitLab integration of a Bugsnag project, which creates GitLab issues from its errors.",
+		CreateContext: resourceGitLabIntegrationCreate,
+		ReadContext:   resourceGitLabIntegrationRead,
+		UpdateContext: resourceGitLabIntegrationUpdate,
+		DeleteContext: resourceGitLabIntegrationDelete,
+		Importer: &schema.ResourceImporter{
+			// the ID is the project ID; the token isn't imported
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema:   s,
+	}
+}
+
+// gitLabProjectPathPattern matches the path of a GitLab project within its
+// group and any subgroups.
+var gitLabProjectPathPattern = regexp.MustCompile(`^[^/\s]+(/[^/\s]+)+$`)
+
+func resourceGitLabIntegrationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutCreate)
+
+	integration, diags := expandGitLabIntegration(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	projectID := d.Get("project_id").(string)
+	if _, err := c.UpdateGitLabIntegration(ctx, projectID, integration); err != nil {
+		return apiErrorDiagnostics("Unable to create the Bugsnag GitLab integration", err)
+	}
+
+	d.SetId(projectID)
+
+	return append(diags, resourceGitLabIntegrationRead(ctx, d, m)...)
+}
+
+func resourceGitLabIntegrationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutRead)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	integration, err := c.GetGitLabIntegration(ctx, d.Id())
+	if errors.Is(err, api.ErrNotFound) && !d.IsNewResource() {
+		log.Printf("[WARN] GitLab integration of Bugsnag project %s not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return apiErrorDiagnostics("Unable to read the Bugsnag GitLab integration", err)
+	}
+
+	attributes := map[string]interface{}{
+		"project_id":   d.Id(),
+		"instance_url": integration.InstanceURL,
+		"project_path": integration.ProjectPath,
+	}
+	for k, v := range attributes {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return append(diags, rateLimitDiagnostics(c)...)
+}
+
+func resourceGitLabIntegrationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutUpdate)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	if d.HasChanges("instance_url", "project_path") || writeOnlyChanged(d, "token") {
+		var integration *api.GitLabIntegration
+		integration, diags = expandGitLabIntegration(d)
+		if diags.HasError() {
+			return diags
+		}
+		if _, err := c.UpdateGitLabIntegration(ctx, d.Id(), integration); err != nil {
+			return apiErrorDiagnostics("Unable to update the Bugsnag GitLab integration", err)
+		}
+	}
+
+	return append(diags, resourceGitLabIntegrationRead(ctx, d, m)...)
+}
+
+func resourceGitLabIntegrationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutDelete)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	// integrations already removed, e.g. with their project, need no removing
+	if err := c.DeleteGitLabIntegration(ctx, d.Id()); err != nil && !errors.Is(err, api.ErrNotFound) {
+		return apiErrorDiagnostics("Unable to delete the Bugsnag GitLab integration", err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// expandGitLabIntegration returns the GitLab integration of a
+// bugsnag_gitlab_integration, with its token only when it has to be sent;
+// the API keeps the current token otherwise.
+func expandGitLabIntegration(d *schema.ResourceData) (*api.GitLabIntegration, diag.Diagnostics) {
+	integration := &api.GitLabIntegration{
+		InstanceURL: d.Get("instance_url").(string),
+		ProjectPath: d.Get("project_path").(string),
+	}
+
+	var diags diag.Diagnostics
+	if writeOnlyChanged(d, "token") {
+		integration.Token, diags = getWriteOnlyString(d, "token")
+	}
+	return integration, diags
+}
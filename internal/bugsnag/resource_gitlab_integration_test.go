@@ -0,0 +1,84 @@
+package bugsnag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-bugsnag/internal/bugsnagtest"
+)
+
+func TestResourceGitLabIntegrationCRUD(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	project := server.AddProject(map[string]interface{}{"name": "checkout"})["id"].(string)
+
+	d := testResourceData(t, resourceGitLabIntegration(), nil, map[string]interface{}{
+		"project_id":   project,
+		"instance_url": "https://gitlab.com",
+		"project_path": "shop/checkout",
+		"token":        "glpat-first",
+	}, c)
+
+	if diags := resourceGitLabIntegrationCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != project {
+		t.Fatalf("unexpected ID %q", d.Id())
+	}
+	if integration := server.GitLabIntegration(project); integration == nil || integration["project_path"] != "shop/checkout" || integration["token"] != "glpat-first" {
+		t.Fatalf("expected the integration to be created with its token, got %v", integration)
+	}
+
+	d = testResourceData(t, resourceGitLabIntegration(), d.State(), map[string]interface{}{
+		"project_id":    project,
+		"instance_url":  "https://gitlab.example.com",
+		"project_path":  "platform/shop/checkout",
+		"token":         "glpat-second",
+		"token_version": 2,
+	}, c)
+
+	if diags := resourceGitLabIntegrationUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	integration := server.GitLabIntegration(project)
+	if integration["instance_url"] != "https://gitlab.example.com" || integration["project_path"] != "platform/shop/checkout" {
+		t.Fatalf("expected the integration to move to the self-hosted project, got %v", integration)
+	}
+	if integration["token"] != "glpat-second" {
+		t.Fatalf("expected the token to be replaced with token_version, got %v", integration["token"])
+	}
+
+	if diags := resourceGitLabIntegrationDelete(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if integration := server.GitLabIntegration(project); integration != nil {
+		t.Fatalf("expected the integration to be deleted, got %v", integration)
+	}
+
+	d.SetId(project)
+	if diags := resourceGitLabIntegrationRead(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected a deleted integration to be removed from state")
+	}
+}
+
+func TestGitLabProjectPathPattern(t *testing.T) {
+	for path, valid := range map[string]bool{
+		"group/project":          true,
+		"group/subgroup/project": true,
+		"project":                false,
+		"/group/project":         false,
+		"group/project/":         false,
+		"group//project":         false,
+		"group/my project":       false,
+	} {
+		if gitLabProjectPathPattern.MatchString(path) != valid {
+			t.Errorf("expected %q to be valid: %t", path, valid)
+		}
+	}
+}
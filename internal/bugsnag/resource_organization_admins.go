@@ -0,0 +1,170 @@
+package bugsnag
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
+)
+
+// resourceOrganizationAdmins manages which collaborators are admins of the
+// organization, authoritatively: admins made outside of Terraform show in the
+// plan, and are demoted by the next apply. There is one per organization.
+func resourceOrganizationAdmins() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages which collaborators are admins of the Bugsnag organization. " +
+			"The list is authoritative: collaborators who aren't listed stop being admins. " +
+			"Destroying it leaves the admins as they are, so that the organization always has one.",
+		CreateContext: resourceOrganizationAdminsCreate,
+		ReadContext:   resourceOrganizationAdminsRead,
+		UpdateContext: resourceOrganizationAdminsUpdate,
+		DeleteContext: resourceOrganizationAdminsDelete,
+		Importer: &schema.ResourceImporter{
+			// the admins are read from the organization whatever the ID
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"collaborator_ids": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				Description: "The IDs of the collaborators who are admins of the organization. At least one is required, so that the organization isn't left without an admin.",
+			},
+			"emails": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The emails of the admins, for reviewing who they are.",
+			},
+			"request_options": requestOptionsSchema(),
+		},
+	}
+}
+
+func resourceOrganizationAdminsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutCreate)
+
+	if diags := reconcileOrganizationAdmins(ctx, d, c); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.UniqueId())
+
+	return resourceOrganizationAdminsRead(ctx, d, m)
+}
+
+func resourceOrganizationAdminsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutRead)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	collaborators, err := c.ListCollaborators(ctx)
+	if err != nil {
+		return apiErrorDiagnostics("Unable to list Bugsnag collaborators", err)
+	}
+
+	ids := make([]string, 0)
+	emails := make([]string, 0)
+	for _, collaborator := range collaborators {
+		if collaborator.IsAdmin {
+			ids = append(ids, collaborator.ID)
+			emails = append(emails, collaborator.Email)
+		}
+	}
+
+	if err := d.Set("collaborator_ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("emails", emails); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return append(diags, rateLimitDiagnostics(c)...)
+}
+
+func resourceOrganizationAdminsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutUpdate)
+
+	if d.HasChange("collaborator_ids") {
+		if diags := reconcileOrganizationAdmins(ctx, d, c); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceOrganizationAdminsRead(ctx, d, m)
+}
+
+func resourceOrganizationAdminsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	// demoting every admin would leave no one able to manage the organization
+	log.Printf("[INFO] leaving the admins of the Bugsnag organization as they are")
+	d.SetId("")
+
+	return diags
+}
+
+// reconcileOrganizationAdmins makes the collaborators in collaborator_ids
+// admins of the organization, and the other admins collaborators. The new
+// admins are promoted before the others are demoted, so that the API token
+// keeps an admin to act as while the admins are handed over.
+func reconcileOrganizationAdmins(ctx context.Context, d *schema.ResourceData, c *Client) diag.Diagnostics {
+	collaborators, err := c.ListCollaborators(ctx)
+	if err != nil {
+		return apiErrorDiagnostics("Unable to list Bugsnag collaborators", err)
+	}
+
+	wanted := expandStringSet(d.Get("collaborator_ids").(*schema.Set))
+	var promote, demote []*api.Collaborator
+	for _, collaborator := range collaborators {
+		switch isWanted := slices.Contains(wanted, collaborator.ID); {
+		case isWanted && !collaborator.IsAdmin:
+			promote = append(promote, collaborator)
+		case !isWanted && collaborator.IsAdmin:
+			demote = append(demote, collaborator)
+		}
+		wanted = slices.DeleteFunc(wanted, func(id string) bool { return id == collaborator.ID })
+	}
+
+	if len(wanted) > 0 {
+		slices.Sort(wanted)
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "collaborator not found",
+			Detail:   fmt.Sprintf("the collaborators %v aren't members of the organization; invite them, e.g. with bugsnag_collaborator, before making them admins.", wanted),
+		}}
+	}
+
+	for _, collaborator := range promote {
+		admin := true
+		if _, err := c.UpdateCollaborator(ctx, collaborator.ID, &api.UpdateCollaboratorRequest{Admin: &admin}); err != nil {
+			return apiErrorDiagnostics(fmt.Sprintf("Unable to make %s an admin of the Bugsnag organization", collaborator.Email), err)
+		}
+	}
+	for _, collaborator := range demote {
+		admin := false
+		if _, err := c.UpdateCollaborator(ctx, collaborator.ID, &api.UpdateCollaboratorRequest{Admin: &admin}); err != nil {
+			return apiErrorDiagnostics(fmt.Sprintf("Unable to remove %s from the admins of the Bugsnag organization", collaborator.Email), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package bugsnag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-bugsnag/internal/bugsnagtest"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
+)
+
+func TestResourceOrganizationAdminsCRUD(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	owner := server.AddCollaborator("owner@example.com")["id"].(string)
+	ops := server.AddCollaborator("ops@example.com")["id"].(string)
+	dev := server.AddCollaborator("dev@example.com")["id"].(string)
+	admin := true
+	if _, err := c.UpdateCollaborator(ctx, dev, &api.UpdateCollaboratorRequest{Admin: &admin}); err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceOrganizationAdmins().Schema, map[string]interface{}{
+		"collaborator_ids": []interface{}{owner, ops},
+	})
+	d.MarkNewResource()
+
+	if diags := resourceOrganizationAdminsCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	admins := map[string]bool{}
+	for _, collaborator := range server.Collaborators() {
+		admins[collaborator["id"].(string)] = collaborator["is_admin"] == true
+	}
+	if !admins[owner] || !admins[ops] || admins[dev] {
+		t.Fatalf("expected only the listed collaborators to be admins, got %v", admins)
+	}
+	if emails := d.Get("emails").(*schema.Set); emails.Len() != 2 || !emails.Contains("ops@example.com") {
+		t.Fatalf("expected the emails of the admins, got %v", emails.List())
+	}
+
+	// an admin made outside of Terraform is read back, to be planned away
+	if _, err := c.UpdateCollaborator(ctx, dev, &api.UpdateCollaboratorRequest{Admin: &admin}); err != nil {
+		t.Fatal(err)
+	}
+	if diags := resourceOrganizationAdminsRead(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if ids := d.Get("collaborator_ids").(*schema.Set); ids.Len() != 3 || !ids.Contains(dev) {
+		t.Fatalf("expected every admin to be read, got %v", ids.List())
+	}
+
+	// the admins are left as they are
+	if diags := resourceOrganizationAdminsDelete(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	for _, collaborator := range server.Collaborators() {
+		if collaborator["is_admin"] != true {
+			t.Fatalf("expected destroying the resource to leave the admins, got %v", collaborator)
+		}
+	}
+}
+
+func TestResourceOrganizationAdminsUnknownCollaborator(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	owner := server.AddCollaborator("owner@example.com")["id"].(string)
+	admin := true
+	if _, err := c.UpdateCollaborator(ctx, owner, &api.UpdateCollaboratorRequest{Admin: &admin}); err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceOrganizationAdmins().Schema, map[string]interface{}{
+		"collaborator_ids": []interface{}{"missing"},
+	})
+	d.MarkNewResource()
+
+	if diags := resourceOrganizationAdminsCreate(ctx, d, c); !diags.HasError() {
+		t.Fatalf("expected an error for a collaborator who isn't a member")
+	}
+	if server.Collaborators()[0]["is_admin"] != true {
+		t.Fatalf("expected no admin to be demoted when a collaborator is missing")
+	}
+}
@@ -0,0 +1,323 @@
+package bugsnag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
+)
+
+// resourceProjectBulk manages many projects with a single resource, e.g. to
+// onboard hundreds of services at once. Projects are created and deleted one
+// request at a time, so that the provider's rate limiting and retries apply
+// to each, and read with a single listing rather than one request each.
+func resourceProjectBulk() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages many Bugsnag projects from a map of their names to their types, such as when onboarding many services at once. " +
+			"Projects that fail to be created are left out of the state, so that the next apply retries them.",
+		CreateContext: resourceProjectBulkCreate,
+		ReadContext:   resourceProjectBulkRead,
+		UpdateContext: resourceProjectBulkUpdate,
+		DeleteContext: resourceProjectBulkDelete,
+		Timeouts:      resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"projects": {
+				Type:     schema.TypeMap,
+				Required: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				ValidateDiagFunc: validateBulkProjects,
+				Description:      "The projects to manage, as a map of their names to their types. An empty type stands for the provider's `default_project_type`. Changing the type of a project replaces it.",
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Manage the existing projects of the same names, if there are any, instead of failing to create duplicates.",
+			},
+			"project_ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The IDs of the projects, by name.",
+			},
+			"api_keys": {
+				Type:      schema.TypeMap,
+				Computed:  true,
+				Sensitive: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The notifier API keys of the projects, by name.",
+			},
+			"request_options": requestOptionsSchema(),
+		},
+	}
+}
+
+// validateBulkProjects validates the name and type of each project of a
+// bugsnag_project_bulk as bugsnag_project does, an empty type aside.
+func validateBulkProjects(v interface{}, path cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for name, projectType := range v.(map[string]interface{}) {
+		diags = append(diags, validateProjectName(name, path.IndexString(name))...)
+		if projectType, _ := projectType.(string); projectType != "" {
+			diags = append(diags, validateProjectType(projectType, path.IndexString(name))...)
+		}
+	}
+	return diags
+}
+
+func resourceProjectBulkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutCreate)
+
+	managed, diags := reconcileBulkProjects(ctx, d, c)
+	if len(managed) == 0 && diags.HasError() {
+		return diags
+	}
+
+	d.SetId(id.UniqueId())
+
+	// a create that fails taints the resource, and replacing it would delete
+	// every project created so far: the projects that failed are left out of
+	// the state instead, for the next apply to create them
+	for i := range diags {
+		if diags[i].Severity == diag.Error {
+			diags[i].Severity = diag.Warning
+			diags[i].Detail += "\n\nThe projects created so far are kept, and the next apply retries this one."
+		}
+	}
+
+	return append(diags, resourceProjectBulkRead(ctx, d, m)...)
+}
+
+func resourceProjectBulkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutRead)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	projectIDs := d.Get("project_ids").(map[string]interface{})
+	projectTypes := d.Get("projects").(map[string]interface{})
+
+	byID := make(map[string]*api.Project, len(projectIDs))
+	for project, err := range c.IterateProjects(ctx) {
+		if err != nil {
+			return apiErrorDiagnostics("Unable to list Bugsnag projects", err)
+		}
+		byID[project.ID] = project
+	}
+
+	ids := make(map[string]interface{}, len(projectIDs))
+	types := make(map[string]interface{}, len(projectIDs))
+	keys := make(map[string]interface{}, len(projectIDs))
+	for name, projectID := range projectIDs {
+		project, ok := byID[projectID.(string)]
+		// a project deleted outside of Terraform is left out, so that the
+		// plan proposes creating it again
+		if !ok {
+			log.Printf("[WARN] Bugsnag project %s (%s) not found, removing it from state", projectID, name)
+			continue
+		}
+		ids[name] = project.ID
+		types[name] = projectTypes[name]
+		keys[name] = project.APIKey
+	}
+
+	state := map[string]interface{}{
+		"projects":    types,
+		"project_ids": ids,
+		"api_keys":    keys,
+	}
+	for k, v := range state {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return append(diags, rateLimitDiagnostics(c)...)
+}
+
+func resourceProjectBulkUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutUpdate)
+
+	// the API keys of the projects created are read even if others failed
+	_, diags := reconcileBulkProjects(ctx, d, c)
+
+	return append(diags, resourceProjectBulkRead(ctx, d, m)...)
+}
+
+func resourceProjectBulkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutDelete)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	projectIDs := d.Get("project_ids").(map[string]interface{})
+	remaining := make(map[string]interface{})
+	for _, name := range sortedKeys(projectIDs) {
+		projectID := projectIDs[name].(string)
+		// projects already deleted outside of Terraform need no deleting
+		if err := c.DeleteProject(ctx, projectID); err != nil && !errors.Is(err, api.ErrNotFound) {
+			diags = append(diags, apiErrorDiagnostics(fmt.Sprintf("Unable to delete Bugsnag project %s", name), err)...)
+			remaining[name] = projectID
+		}
+	}
+
+	// the projects that failed to be deleted stay in state, for the next
+	// destroy to retry them
+	if diags.HasError() {
+		if err := d.Set("project_ids", remaining); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	return diags
+}
+
+// reconcileBulkProjects deletes the projects no longer configured, or of
+// another type than configured, and creates the configured projects that
+// don't exist yet. It carries on past failures, and records the projects that
+// exist in projects and project_ids, so that the failed changes are planned
+// again. It returns the names and IDs of the projects that exist.
+func reconcileBulkProjects(ctx context.Context, d *schema.ResourceData, c *Client) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// the projects that exist are listed once, rather than read or searched
+	// for by name one project at a time
+	byID := make(map[string]*api.Project)
+	byName := make(map[string][]*api.Project)
+	for project, err := range c.IterateProjects(ctx) {
+		if err != nil {
+			return nil, apiErrorDiagnostics("Unable to list Bugsnag projects", err)
+		}
+		byID[project.ID] = project
+		byName[project.Name] = append(byName[project.Name], project)
+	}
+
+	wanted := d.Get("projects").(map[string]interface{})
+	wantedType := func(name string) string {
+		if projectType, _ := wanted[name].(string); projectType != "" {
+			return projectType
+		}
+		return c.DefaultProjectType
+	}
+
+	managed := make(map[string]interface{})
+	types := make(map[string]interface{})
+	projectIDs := d.Get("project_ids").(map[string]interface{})
+	for _, name := range sortedKeys(projectIDs) {
+		project, ok := byID[projectIDs[name].(string)]
+		// a project deleted outside of Terraform is created again
+		if !ok {
+			continue
+		}
+		if _, ok := wanted[name]; ok && project.Type == wantedType(name) {
+			managed[name] = project.ID
+			types[name] = wanted[name]
+			continue
+		}
+
+		log.Printf("[INFO] deleting the Bugsnag project %s", name)
+		if err := c.DeleteProject(ctx, project.ID); err != nil && !errors.Is(err, api.ErrNotFound) {
+			diags = append(diags, apiErrorDiagnostics(fmt.Sprintf("Unable to delete Bugsnag project %s", name), err)...)
+			managed[name] = project.ID
+			types[name] = project.Type
+			continue
+		}
+		byName[name] = slices.DeleteFunc(byName[name], func(p *api.Project) bool { return p.ID == project.ID })
+	}
+
+	for _, name := range sortedKeys(wanted) {
+		if _, ok := managed[name]; ok {
+			continue
+		}
+
+		project, projectDiags := createBulkProject(ctx, d, c, name, wantedType(name), byName[name])
+		if projectDiags.HasError() {
+			diags = append(diags, projectDiags...)
+			continue
+		}
+		managed[name] = project.ID
+		types[name] = wanted[name]
+	}
+
+	if err := d.Set("project_ids", managed); err != nil {
+		return managed, append(diags, diag.FromErr(err)...)
+	}
+	if diags.HasError() {
+		if err := d.Set("projects", types); err != nil {
+			return managed, append(diags, diag.FromErr(err)...)
+		}
+	}
+	return managed, diags
+}
+
+// createBulkProject creates the project name of a bugsnag_project_bulk, or
+// adopts the existing project of that name.
+func createBulkProject(ctx context.Context, d *schema.ResourceData, c *Client, name, projectType string, existing []*api.Project) (*api.Project, diag.Diagnostics) {
+	if projectType == "" {
+		return nil, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "project type not set",
+			Detail:   fmt.Sprintf(`the project %s has no type; please set its type in projects, or default_project_type on the provider.`, name),
+		}}
+	}
+
+	switch {
+	case c.SkipDuplicateNameCheck && !d.Get("adopt_existing").(bool):
+	case len(existing) == 1 && d.Get("adopt_existing").(bool):
+		if existing[0].Type != projectType {
+			return nil, diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "existing project has another type",
+				Detail:   fmt.Sprintf(`the existing project %s has type %s, not %s.`, name, existing[0].Type, projectType),
+			}}
+		}
+		log.Printf("[INFO] adopting the existing Bugsnag project %s", existing[0].ID)
+		return existing[0], nil
+	case len(existing) > 0:
+		detail := fmt.Sprintf(`a project named %s already exists.`, name)
+		if len(existing) > 1 {
+			detail = fmt.Sprintf(`%d projects are named %s, so adopt_existing can't tell which one to manage.`, len(existing), name)
+		} else {
+			detail += ` Set adopt_existing to manage it with Terraform instead.`
+		}
+		return nil, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "duplicate project name",
+			Detail:   detail,
+		}}
+	}
+
+	project, err := c.CreateProject(ctx, &api.CreateProjectRequest{Name: name, Type: projectType, IgnoreOldBrowsers: c.DefaultIgnoreOldBrowsers})
+	if err != nil {
+		return nil, apiErrorDiagnostics(fmt.Sprintf("Unable to create Bugsnag project %s", name), err)
+	}
+	return project, nil
+}
+
+// sortedKeys returns the keys of m in order, so that the projects of a
+// bugsnag_project_bulk are always created and deleted in the same order.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,139 @@
+package bugsnag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-bugsnag/internal/bugsnagtest"
+)
+
+func TestResourceProjectBulkCRUD(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	d := schema.TestResourceDataRaw(t, resourceProjectBulk().Schema, map[string]interface{}{
+		"projects": map[string]interface{}{
+			"api":    "",
+			"web":    "js",
+			"worker": "go",
+		},
+	})
+	d.MarkNewResource()
+
+	if diags := resourceProjectBulkCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() == "" {
+		t.Fatalf("expected an ID to be set")
+	}
+	ids := d.Get("project_ids").(map[string]interface{})
+	keys := d.Get("api_keys").(map[string]interface{})
+	if len(ids) != 3 || len(keys) != 3 {
+		t.Fatalf("expected the IDs and API keys of 3 projects, got %v and %v", ids, keys)
+	}
+	if project := server.Project(ids["api"].(string)); project["type"] != "go" {
+		t.Fatalf("expected an empty type to default to the provider's, got %v", project["type"])
+	}
+
+	// web changes type, worker is removed, cli is added
+	d = schema.TestResourceDataRaw(t, resourceProjectBulk().Schema, map[string]interface{}{
+		"projects": map[string]interface{}{
+			"api": "",
+			"web": "react",
+			"cli": "go",
+		},
+	})
+	d.SetId("bulk")
+	if err := d.Set("project_ids", ids); err != nil {
+		t.Fatal(err)
+	}
+
+	if diags := resourceProjectBulkUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	updated := d.Get("project_ids").(map[string]interface{})
+	if updated["api"] != ids["api"] {
+		t.Fatalf("expected the unchanged project to be kept, got %v", updated["api"])
+	}
+	if updated["web"] == ids["web"] || server.Project(ids["web"].(string)) != nil {
+		t.Fatalf("expected the project whose type changed to be replaced")
+	}
+	if project := server.Project(updated["web"].(string)); project["type"] != "react" {
+		t.Fatalf("expected the replacement to have the new type, got %v", project["type"])
+	}
+	if _, ok := updated["worker"]; ok || server.Project(ids["worker"].(string)) != nil {
+		t.Fatalf("expected the removed project to be deleted")
+	}
+	if _, ok := updated["cli"]; !ok {
+		t.Fatalf("expected the added project to be created, got %v", updated)
+	}
+
+	server.DeleteProject(updated["cli"].(string))
+	if diags := resourceProjectBulkRead(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if _, ok := d.Get("projects").(map[string]interface{})["cli"]; ok {
+		t.Fatalf("expected the project deleted outside of Terraform to be removed from state")
+	}
+
+	if diags := resourceProjectBulkDelete(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	for name, projectID := range d.Get("project_ids").(map[string]interface{}) {
+		if server.Project(projectID.(string)) != nil {
+			t.Fatalf("expected the project %s to be deleted", name)
+		}
+	}
+}
+
+func TestResourceProjectBulkPartialCreate(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	// b fails as a duplicate of an existing project
+	server.AddProject(map[string]interface{}{"name": "b", "type": "go"})
+
+	d := schema.TestResourceDataRaw(t, resourceProjectBulk().Schema, map[string]interface{}{
+		"projects": map[string]interface{}{
+			"a": "go",
+			"b": "go",
+		},
+	})
+	d.MarkNewResource()
+
+	diags := resourceProjectBulkCreate(ctx, d, c)
+	if diags.HasError() {
+		t.Fatalf("expected the failed project to be a warning, got %v", diags)
+	}
+	if len(diags) == 0 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a warning about the failed project, got %v", diags)
+	}
+	if d.Id() == "" {
+		t.Fatalf("expected the created projects to be kept in state")
+	}
+	projects := d.Get("projects").(map[string]interface{})
+	if _, ok := projects["a"]; !ok {
+		t.Fatalf("expected the created project to be in state, got %v", projects)
+	}
+	if _, ok := projects["b"]; ok {
+		t.Fatalf("expected the failed project to be left out of state, got %v", projects)
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceProjectBulk().Schema, map[string]interface{}{
+		"projects":       map[string]interface{}{"b": "go"},
+		"adopt_existing": true,
+	})
+	d.MarkNewResource()
+	if diags := resourceProjectBulkCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if _, ok := d.Get("project_ids").(map[string]interface{})["b"]; !ok {
+		t.Fatalf("expected the existing project to be adopted")
+	}
+}
@@ -0,0 +1,187 @@
+package bugsnag
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
+)
+
+// resourceProjectSettings manages the grouping, discard and whitelist
+// settings of a project apart from bugsnag_project, so that the team owning
+// the project and the teams owning its settings each have a resource of
+// their own.
+func resourceProjectSettings() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages the grouping, discard and URL whitelist settings of a Bugsnag project, apart from the `bugsnag_project` resource that created it. " +
+			"Don't also set these arguments on `bugsnag_project`. Settings left unset keep their values; set them to `[]` to empty them. " +
+			"Destroying this resource leaves the settings as they are.",
+		CreateContext: resourceProjectSettingsCreate,
+		ReadContext:   resourceProjectSettingsRead,
+		UpdateContext: resourceProjectSettingsUpdate,
+		DeleteContext: resourceProjectSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			// the ID is the project ID
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "The ID of the project.",
+			},
+			"global_grouping": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The error classes to group into a single error regardless of where they occur.",
+			},
+			"location_grouping": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The error classes to group by where they occur, regardless of their message.",
+			},
+			"discarded_app_versions": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The app versions whose events to discard.",
+			},
+			"discarded_errors": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The error classes whose events to discard.",
+			},
+			"url_whitelist": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set:         hashURLWhitelistEntry,
+				Description: "The URLs of the pages errors are accepted from, for browser projects. Entries are compared as Bugsnag normalizes them, ignoring case and trailing slashes. Left unset, the project keeps its whitelist; set it to `[]` to accept errors from every URL.",
+			},
+			"request_options": requestOptionsSchema(),
+		},
+	}
+}
+
+// projectSettingsRequest returns the update of the settings for which
+// include returns true.
+func projectSettingsRequest(d *schema.ResourceData, include func(key string) bool) *api.UpdateProjectRequest {
+	request := &api.UpdateProjectRequest{}
+	fields := map[string]**[]string{
+		"global_grouping":        &request.GlobalGrouping,
+		"location_grouping":      &request.LocationGrouping,
+		"discarded_app_versions": &request.DiscardedAppVersions,
+		"discarded_errors":       &request.DiscardedErrors,
+		"url_whitelist":          &request.URLWhitelist,
+	}
+	for key, field := range fields {
+		if include(key) {
+			values := expandStringSet(d.Get(key).(*schema.Set))
+			*field = &values
+		}
+	}
+	return request
+}
+
+func resourceProjectSettingsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutCreate)
+
+	// as with bugsnag_project, the settings left unset keep their values;
+	// those set, even to [], are applied
+	request := projectSettingsRequest(d, func(key string) bool {
+		v, diags := d.GetRawConfigAt(cty.GetAttrPath(key))
+		return !diags.HasError() && !v.IsNull()
+	})
+
+	projectID := d.Get("project_id").(string)
+	if _, err := c.UpdateProject(ctx, projectID, request); err != nil {
+		return apiErrorDiagnostics("Unable to update Bugsnag project settings", err)
+	}
+
+	d.SetId(projectID)
+
+	return resourceProjectSettingsRead(ctx, d, m)
+}
+
+func resourceProjectSettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutRead)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	project, err := c.GetProject(ctx, d.Id())
+	if errors.Is(err, api.ErrNotFound) && !d.IsNewResource() {
+		log.Printf("[WARN] Bugsnag project %s not found, removing its settings from state", d.Id())
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return apiErrorDiagnostics("Unable to read Bugsnag project settings", err)
+	}
+
+	settings := map[string]interface{}{
+		"project_id":             project.ID,
+		"global_grouping":        project.GlobalGrouping,
+		"location_grouping":      project.LocationGrouping,
+		"discarded_app_versions": project.DiscardedAppVersions,
+		"discarded_errors":       project.DiscardedErrors,
+		"url_whitelist":          project.URLWhitelist,
+	}
+	for k, v := range settings {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return append(diags, rateLimitDiagnostics(c)...)
+}
+
+func resourceProjectSettingsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutUpdate)
+
+	if d.HasChangeExcept("request_options") {
+		request := projectSettingsRequest(d, d.HasChange)
+		if _, err := c.UpdateProject(ctx, d.Id(), request); err != nil {
+			return apiErrorDiagnostics("Unable to update Bugsnag project settings", err)
+		}
+	}
+
+	return resourceProjectSettingsRead(ctx, d, m)
+}
+
+// resourceProjectSettingsDelete only removes the settings from state: the
+// project keeps them, since it has no settings to go back to.
+func resourceProjectSettingsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	log.Printf("[INFO] leaving the settings of Bugsnag project %s as they are", d.Id())
+	d.SetId("")
+	return nil
+}
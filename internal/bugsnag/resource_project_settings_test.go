@@ -0,0 +1,81 @@
+package bugsnag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-bugsnag/internal/bugsnagtest"
+)
+
+func TestResourceProjectSettingsCRUD(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	project := server.AddProject(map[string]interface{}{
+		"name":            "web",
+		"type":            "js",
+		"global_grouping": []interface{}{"ChunkLoadError"},
+
+		"discarded_app_versions": []interface{}{"1.0.0"},
+	})
+	id := project["id"].(string)
+
+	d := testResourceData(t, resourceProjectSettings(), nil, map[string]interface{}{
+		"project_id":             id,
+		"discarded_errors":       []interface{}{"ResizeObserverError"},
+		"discarded_app_versions": []interface{}{},
+		"url_whitelist":          []interface{}{"Example.com/"},
+	}, c)
+
+	if diags := resourceProjectSettingsCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != id {
+		t.Fatalf("expected the ID to be the project ID, got %q", d.Id())
+	}
+	stored := server.Project(id)
+	if discarded := stored["discarded_errors"].([]interface{}); len(discarded) != 1 || discarded[0] != "ResizeObserverError" {
+		t.Fatalf("expected the discarded errors to be applied, got %v", discarded)
+	}
+	if versions := stored["discarded_app_versions"].([]interface{}); len(versions) != 0 {
+		t.Fatalf("expected the settings set to [] to be emptied, got %v", versions)
+	}
+	if grouping := d.Get("global_grouping").(*schema.Set); !grouping.Contains("ChunkLoadError") {
+		t.Fatalf("expected the unset settings to keep their values, got %v", grouping.List())
+	}
+
+	d = testResourceData(t, resourceProjectSettings(), d.State(), map[string]interface{}{
+		"project_id":       id,
+		"discarded_errors": []interface{}{},
+	}, c)
+
+	if diags := resourceProjectSettingsUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	stored = server.Project(id)
+	if discarded := stored["discarded_errors"].([]interface{}); len(discarded) != 0 {
+		t.Fatalf("expected the discarded errors to be emptied, got %v", discarded)
+	}
+	if whitelist := stored["url_whitelist"].([]interface{}); len(whitelist) != 1 {
+		t.Fatalf("expected the unchanged settings to be left alone, got %v", whitelist)
+	}
+
+	if diags := resourceProjectSettingsDelete(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if server.Project(id) == nil {
+		t.Fatalf("expected the project to be left alone")
+	}
+
+	server.DeleteProject(id)
+	d.SetId(id)
+	if diags := resourceProjectSettingsRead(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected the settings of a deleted project to be removed from state")
+	}
+}
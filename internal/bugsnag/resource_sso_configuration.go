@@ -0,0 +1,152 @@
+package bugsnag
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
+)
+
+// resourceSSOConfiguration manages how the members of the organization sign
+// in with SAML. There is one per organization; creating it over an existing
+// configuration takes it over.
+func resourceSSOConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages the SAML single sign-on configuration of the Bugsnag organization. " +
+			"Destroying it disables SSO, so that members sign in with a password again. " +
+			"Enforce SSO only once signing in with the identity provider works, so that members aren't locked out.",
+		CreateContext: resourceSSOConfigurationCreate,
+		ReadContext:   resourceSSOConfigurationRead,
+		UpdateContext: resourceSSOConfigurationUpdate,
+		DeleteContext: resourceSSOConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			// the configuration is read from the organization whatever the ID
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"idp_metadata_url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.IsURLWithHTTPS,
+				Description:  "The URL of the SAML metadata of the identity provider.",
+			},
+			"enforced": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether members can only sign in with SSO, rather than with a password too.",
+			},
+			"default_role": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      api.SSORoleCollaborator,
+				ValidateFunc: validation.StringInSlice([]string{api.SSORoleCollaborator, api.SSORoleAdmin}, false),
+				Description:  "The role people who sign in with SSO for the first time join the organization with: `collaborator`, with access to no project until they are given some, or `admin`.",
+			},
+			"entity_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The entity ID of Bugsnag as a service provider, to configure the identity provider with.",
+			},
+			"acs_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The assertion consumer service URL of Bugsnag, to configure the identity provider with.",
+			},
+			"request_options": requestOptionsSchema(),
+		},
+	}
+}
+
+func resourceSSOConfigurationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutCreate)
+
+	if _, err := c.UpdateSSOConfiguration(ctx, expandSSOConfiguration(d)); err != nil {
+		return apiErrorDiagnostics("Unable to configure SSO for the Bugsnag organization", err)
+	}
+
+	d.SetId(id.UniqueId())
+
+	return resourceSSOConfigurationRead(ctx, d, m)
+}
+
+func resourceSSOConfigurationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutRead)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	// SSO disabled outside of Terraform is planned to be configured again
+	configuration, err := c.GetSSOConfiguration(ctx)
+	if errors.Is(err, api.ErrNotFound) && !d.IsNewResource() {
+		log.Printf("[WARN] Bugsnag SSO configuration not found, removing from state")
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return apiErrorDiagnostics("Unable to read the Bugsnag SSO configuration", err)
+	}
+
+	attributes := map[string]interface{}{
+		"idp_metadata_url": configuration.IdPMetadataURL,
+		"enforced":         configuration.Enforced,
+		"default_role":     configuration.DefaultRole,
+		"entity_id":        configuration.EntityID,
+		"acs_url":          configuration.ACSURL,
+	}
+	for k, v := range attributes {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return append(diags, rateLimitDiagnostics(c)...)
+}
+
+func resourceSSOConfigurationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutUpdate)
+
+	if d.HasChanges("idp_metadata_url", "enforced", "default_role") {
+		if _, err := c.UpdateSSOConfiguration(ctx, expandSSOConfiguration(d)); err != nil {
+			return apiErrorDiagnostics("Unable to update the Bugsnag SSO configuration", err)
+		}
+	}
+
+	return resourceSSOConfigurationRead(ctx, d, m)
+}
+
+func resourceSSOConfigurationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutDelete)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	// SSO already disabled outside of Terraform needs no disabling
+	if err := c.DeleteSSOConfiguration(ctx); err != nil && !errors.Is(err, api.ErrNotFound) {
+		return apiErrorDiagnostics("Unable to disable SSO for the Bugsnag organization", err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// expandSSOConfiguration returns the SSO configuration of a
+// bugsnag_sso_configuration, which the API replaces as a whole.
+func expandSSOConfiguration(d *schema.ResourceData) *api.SSOConfiguration {
+	return &api.SSOConfiguration{
+		IdPMetadataURL: d.Get("idp_metadata_url").(string),
+		Enforced:       d.Get("enforced").(bool),
+		DefaultRole:    d.Get("default_role").(string),
+	}
+}
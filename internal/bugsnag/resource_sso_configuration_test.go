@@ -0,0 +1,62 @@
+package bugsnag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-bugsnag/internal/bugsnagtest"
+)
+
+func TestResourceSSOConfigurationCRUD(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	d := schema.TestResourceDataRaw(t, resourceSSOConfiguration().Schema, map[string]interface{}{
+		"idp_metadata_url": "https://idp.example.com/metadata",
+	})
+	d.MarkNewResource()
+
+	if diags := resourceSSOConfigurationCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	sso := server.SSOConfiguration()
+	if sso == nil || sso["enforced"] != false || sso["default_role"] != "collaborator" {
+		t.Fatalf("expected SSO to be configured without enforcing it, got %v", sso)
+	}
+	if d.Get("entity_id") == "" || d.Get("acs_url") == "" {
+		t.Fatalf("expected the service provider details to be read")
+	}
+
+	id := d.Id()
+	d = schema.TestResourceDataRaw(t, resourceSSOConfiguration().Schema, map[string]interface{}{
+		"idp_metadata_url": "https://idp.example.com/metadata",
+		"enforced":         true,
+		"default_role":     "collaborator",
+	})
+	d.SetId(id)
+
+	if diags := resourceSSOConfigurationUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if sso := server.SSOConfiguration(); sso["enforced"] != true {
+		t.Fatalf("expected SSO to be enforced, got %v", sso)
+	}
+
+	if diags := resourceSSOConfigurationDelete(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if sso := server.SSOConfiguration(); sso != nil {
+		t.Fatalf("expected SSO to be disabled, got %v", sso)
+	}
+
+	d.SetId(id)
+	if diags := resourceSSOConfigurationRead(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected disabled SSO to be removed from state")
+	}
+}
@@ -0,0 +1,144 @@
+package bugsnag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
+)
+
+// resourceTeamProjectAssignment gives a team access to a project. Referencing
+// the ID of a bugsnag_project in project_id gives the team access to a new
+// project in the same apply that creates it.
+func resourceTeamProjectAssignment() *schema.Resource {
+	return &schema.Resource{
+		Description: "Gives the members of a Bugsnag team access to a project. " +
+			"Destroying it revokes the access of the team; members who can access the project otherwise keep their access.",
+		CreateContext: resourceTeamProjectAssignmentCreate,
+		ReadContext:   resourceTeamProjectAssignmentRead,
+		UpdateContext: resourceTeamProjectAssignmentUpdate,
+		DeleteContext: resourceTeamProjectAssignmentDelete,
+		Importer: &schema.ResourceImporter{
+			// the ID is the team ID and the project ID, e.g. team_id/project_id
+			StateContext: resourceTeamProjectAssignmentImport,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"team_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "The ID of the team.",
+			},
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "The ID of the project to give the team access to.",
+			},
+			"permission": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      api.TeamPermissionMember,
+				ValidateFunc: validation.StringInSlice([]string{api.TeamPermissionMember, api.TeamPermissionAdmin}, false),
+				Description:  "The permission of the team on the project: `member` to triage its errors, or `admin` to change its settings too.",
+			},
+			"request_options": requestOptionsSchema(),
+		},
+	}
+}
+
+func resourceTeamProjectAssignmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutCreate)
+
+	teamID := d.Get("team_id").(string)
+	projectID := d.Get("project_id").(string)
+	if _, err := c.SetTeamProject(ctx, teamID, projectID, d.Get("permission").(string)); err != nil {
+		return apiErrorDiagnostics("Unable to give the Bugsnag team access to the project", err)
+	}
+
+	d.SetId(teamID + "/" + projectID)
+
+	return resourceTeamProjectAssignmentRead(ctx, d, m)
+}
+
+func resourceTeamProjectAssignmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutRead)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	// access revoked outside of Terraform, or a team or project deleted, is
+	// planned to be given again
+	access, err := c.GetTeamProject(ctx, d.Get("team_id").(string), d.Get("project_id").(string))
+	if errors.Is(err, api.ErrNotFound) && !d.IsNewResource() {
+		log.Printf("[WARN] Bugsnag team project assignment %s not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return apiErrorDiagnostics("Unable to read the Bugsnag team project assignment", err)
+	}
+
+	if err := d.Set("permission", access.Permission); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return append(diags, rateLimitDiagnostics(c)...)
+}
+
+func resourceTeamProjectAssignmentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutUpdate)
+
+	if d.HasChange("permission") {
+		if _, err := c.SetTeamProject(ctx, d.Get("team_id").(string), d.Get("project_id").(string), d.Get("permission").(string)); err != nil {
+			return apiErrorDiagnostics("Unable to update the Bugsnag team project assignment", err)
+		}
+	}
+
+	return resourceTeamProjectAssignmentRead(ctx, d, m)
+}
+
+func resourceTeamProjectAssignmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutDelete)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	// access already revoked, e.g. by deleting the project, needs no revoking
+	if err := c.RemoveTeamProject(ctx, d.Get("team_id").(string), d.Get("project_id").(string)); err != nil && !errors.Is(err, api.ErrNotFound) {
+		return apiErrorDiagnostics("Unable to revoke the access of the Bugsnag team to the project", err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+func resourceTeamProjectAssignmentImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	teamID, projectID, ok := strings.Cut(d.Id(), "/")
+	if !ok || teamID == "" || projectID == "" {
+		return nil, fmt.Errorf("unexpected import ID %q, expected team_id/project_id", d.Id())
+	}
+
+	if err := d.Set("team_id", teamID); err != nil {
+		return nil, err
+	}
+	if err := d.Set("project_id", projectID); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
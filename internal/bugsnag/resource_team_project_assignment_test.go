@@ -0,0 +1,84 @@
+package bugsnag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-bugsnag/internal/bugsnagtest"
+)
+
+func TestResourceTeamProjectAssignmentCRUD(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	team := server.AddTeam()
+	project := server.AddProject(map[string]interface{}{"name": "checkout"})["id"].(string)
+
+	d := schema.TestResourceDataRaw(t, resourceTeamProjectAssignment().Schema, map[string]interface{}{
+		"team_id":    team,
+		"project_id": project,
+	})
+	d.MarkNewResource()
+
+	if diags := resourceTeamProjectAssignmentCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != team+"/"+project {
+		t.Fatalf("unexpected ID %q", d.Id())
+	}
+	if permission := server.TeamProjects(team)[project]; permission != "member" {
+		t.Fatalf("expected the team to be given member access, got %v", permission)
+	}
+
+	id := d.Id()
+	d = schema.TestResourceDataRaw(t, resourceTeamProjectAssignment().Schema, map[string]interface{}{
+		"team_id":    team,
+		"project_id": project,
+		"permission": "admin",
+	})
+	d.SetId(id)
+
+	if diags := resourceTeamProjectAssignmentUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if permission := server.TeamProjects(team)[project]; permission != "admin" {
+		t.Fatalf("expected the permission to be changed, got %v", permission)
+	}
+
+	if diags := resourceTeamProjectAssignmentDelete(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if len(server.TeamProjects(team)) != 0 {
+		t.Fatalf("expected the access of the team to be revoked")
+	}
+
+	d.SetId(id)
+	if diags := resourceTeamProjectAssignmentRead(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected revoked access to be removed from state")
+	}
+}
+
+func TestResourceTeamProjectAssignmentImport(t *testing.T) {
+	d := resourceTeamProjectAssignment().TestResourceData()
+	d.SetId("team/project")
+
+	if _, err := resourceTeamProjectAssignmentImport(context.Background(), d, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if d.Get("team_id") != "team" || d.Get("project_id") != "project" {
+		t.Fatalf("expected the IDs to be parsed, got %v and %v", d.Get("team_id"), d.Get("project_id"))
+	}
+
+	for _, id := range []string{"team", "/project", "team/"} {
+		d.SetId(id)
+		if _, err := resourceTeamProjectAssignmentImport(context.Background(), d, nil); err == nil {
+			t.Fatalf("expected an error importing %q", id)
+		}
+	}
+}
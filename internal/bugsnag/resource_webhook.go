@@ -0,0 +1,188 @@
+package bugsnag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
+)
+
+// resourceWebhook forwards the events of a project to a URL, e.g. of
+// internal incident tooling. Its signing secret is write-only; see
+// writeOnlySecretSchema.
+func resourceWebhook() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"project_id": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "The ID of the project whose events are forwarded.",
+		},
+		"url": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsURLWithHTTPS,
+			Description:  "The URL Bugsnag sends the events to.",
+		},
+		"triggers": {
+			Type:     schema.TypeSet,
+			Required: true,
+			MinItems: 1,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice(api.WebhookTriggers, false),
+			},
+			Description: fmt.Sprintf("The events that are forwarded: any of `%s`.", strings.Join(api.WebhookTriggers, "`, `")),
+		},
+		"request_options": requestOptionsSchema(),
+	}
+	for k, v := range writeOnlySecretSchema("secret", "The secret Bugsnag signs the requests with, so that the receiver can check they come from Bugsnag. Without it, requests aren't signed.") {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		Description:   "Manages a webhook forwarding the events of a Bugsnag project to a URL.",
+		CreateContext: resourceWebhookCreate,
+		ReadContext:   resourceWebhookRead,
+		UpdateContext: resourceWebhookUpdate,
+		DeleteContext: resourceWebhookDelete,
+		Importer: &schema.ResourceImporter{
+			// the ID is the project ID and the webhook ID, e.g.
+			// project_id/webhook_id; the secret isn't imported
+			StateContext: resourceWebhookImport,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema:   s,
+	}
+}
+
+func resourceWebhookCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutCreate)
+
+	secret, diags := getWriteOnlyString(d, "secret")
+	if diags.HasError() {
+		return diags
+	}
+
+	projectID := d.Get("project_id").(string)
+	webhook, err := c.CreateWebhook(ctx, projectID, &api.CreateWebhookRequest{
+		URL:      d.Get("url").(string),
+		Secret:   secret,
+		Triggers: expandStringSet(d.Get("triggers").(*schema.Set)),
+	})
+	if err != nil {
+		return apiErrorDiagnostics("Unable to create the Bugsnag webhook", err)
+	}
+
+	d.SetId(projectID + "/" + webhook.ID)
+
+	return append(diags, resourceWebhookRead(ctx, d, m)...)
+}
+
+func resourceWebhookRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutRead)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	projectID, webhookID := parseWebhookID(d.Id())
+	webhook, err := c.GetWebhook(ctx, projectID, webhookID)
+	if errors.Is(err, api.ErrNotFound) && !d.IsNewResource() {
+		log.Printf("[WARN] Bugsnag webhook %s not found, removing from state", d.Id())
+		d.SetId("")
+		return diags
+	}
+	if err != nil {
+		return apiErrorDiagnostics("Unable to read the Bugsnag webhook", err)
+	}
+
+	attributes := map[string]interface{}{
+		"project_id": projectID,
+		"url":        webhook.URL,
+		"triggers":   webhook.Triggers,
+	}
+	for k, v := range attributes {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return append(diags, rateLimitDiagnostics(c)...)
+}
+
+func resourceWebhookUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutUpdate)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	request := &api.UpdateWebhookRequest{}
+	if d.HasChange("url") {
+		url := d.Get("url").(string)
+		request.URL = &url
+	}
+	if d.HasChange("triggers") {
+		triggers := expandStringSet(d.Get("triggers").(*schema.Set))
+		request.Triggers = &triggers
+	}
+	if writeOnlyChanged(d, "secret") {
+		var secret string
+		secret, diags = getWriteOnlyString(d, "secret")
+		if diags.HasError() {
+			return diags
+		}
+		request.Secret = &secret
+	}
+
+	if request.URL != nil || request.Triggers != nil || request.Secret != nil {
+		projectID, webhookID := parseWebhookID(d.Id())
+		if _, err := c.UpdateWebhook(ctx, projectID, webhookID, request); err != nil {
+			return apiErrorDiagnostics("Unable to update the Bugsnag webhook", err)
+		}
+	}
+
+	return append(diags, resourceWebhookRead(ctx, d, m)...)
+}
+
+func resourceWebhookDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+	ctx = withRequestOptions(ctx, d, schema.TimeoutDelete)
+
+	// Warning or errors can be collected in a slice type
+	var diags diag.Diagnostics
+
+	// webhooks already deleted, e.g. with their project, need no deleting
+	projectID, webhookID := parseWebhookID(d.Id())
+	if err := c.DeleteWebhook(ctx, projectID, webhookID); err != nil && !errors.Is(err, api.ErrNotFound) {
+		return apiErrorDiagnostics("Unable to delete the Bugsnag webhook", err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+func resourceWebhookImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if projectID, webhookID := parseWebhookID(d.Id()); projectID == "" || webhookID == "" {
+		return nil, fmt.Errorf("unexpected import ID %q, expected project_id/webhook_id", d.Id())
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// parseWebhookID returns the project ID and the webhook ID of the ID of a
+// bugsnag_webhook, which the webhook API needs both of.
+func parseWebhookID(id string) (projectID, webhookID string) {
+	projectID, webhookID, _ = strings.Cut(id, "/")
+	return projectID, webhookID
+}
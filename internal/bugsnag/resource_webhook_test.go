@@ -0,0 +1,105 @@
+package bugsnag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-bugsnag/internal/bugsnagtest"
+)
+
+func TestResourceWebhookCRUD(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	c := newTestClient(server)
+	ctx := context.Background()
+
+	project := server.AddProject(map[string]interface{}{"name": "checkout"})["id"].(string)
+
+	d := testResourceData(t, resourceWebhook(), nil, map[string]interface{}{
+		"project_id": project,
+		"url":        "https://incidents.example.com/bugsnag",
+		"triggers":   []interface{}{"new_error", "error_spike"},
+		"secret":     "signing-secret",
+	}, c)
+
+	if diags := resourceWebhookCreate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	webhooks := server.Webhooks(project)
+	if len(webhooks) != 1 || d.Id() != project+"/"+webhooks[0]["id"].(string) {
+		t.Fatalf("expected the webhook to be created, got %v and ID %q", webhooks, d.Id())
+	}
+	if webhooks[0]["secret"] != "signing-secret" {
+		t.Fatalf("expected the secret to be sent on create, got %v", webhooks[0]["secret"])
+	}
+	if triggers := d.Get("triggers").(*schema.Set); triggers.Len() != 2 || !triggers.Contains("error_spike") {
+		t.Fatalf("expected the triggers to be read, got %v", triggers.List())
+	}
+
+	id := d.Id()
+	d = testResourceData(t, resourceWebhook(), d.State(), map[string]interface{}{
+		"project_id": project,
+		"url":        "https://incidents.example.com/v2/bugsnag",
+		"triggers":   []interface{}{"every_event"},
+		"secret":     "rotated-secret",
+	}, c)
+
+	if diags := resourceWebhookUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	webhook := server.Webhooks(project)[0]
+	if webhook["url"] != "https://incidents.example.com/v2/bugsnag" {
+		t.Fatalf("expected the URL to be updated, got %v", webhook["url"])
+	}
+	if triggers := webhook["triggers"].([]interface{}); len(triggers) != 1 || triggers[0] != "every_event" {
+		t.Fatalf("expected the triggers to be replaced, got %v", triggers)
+	}
+	if webhook["secret"] != "signing-secret" {
+		t.Fatalf("expected the secret to be kept while secret_version is unchanged, got %v", webhook["secret"])
+	}
+
+	d = testResourceData(t, resourceWebhook(), d.State(), map[string]interface{}{
+		"project_id":     project,
+		"url":            "https://incidents.example.com/v2/bugsnag",
+		"triggers":       []interface{}{"every_event"},
+		"secret":         "rotated-secret",
+		"secret_version": 2,
+	}, c)
+
+	if diags := resourceWebhookUpdate(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if secret := server.Webhooks(project)[0]["secret"]; secret != "rotated-secret" {
+		t.Fatalf("expected the secret to be rotated with secret_version, got %v", secret)
+	}
+
+	if diags := resourceWebhookDelete(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if len(server.Webhooks(project)) != 0 {
+		t.Fatalf("expected the webhook to be deleted")
+	}
+
+	d.SetId(id)
+	if diags := resourceWebhookRead(ctx, d, c); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected a deleted webhook to be removed from state")
+	}
+}
+
+func TestResourceWebhookImport(t *testing.T) {
+	for id, valid := range map[string]bool{
+		"project/webhook": true,
+		"webhook":         false,
+		"project/":        false,
+	} {
+		d := resourceWebhook().TestResourceData()
+		d.SetId(id)
+		if _, err := resourceWebhookImport(context.Background(), d, nil); (err == nil) != valid {
+			t.Errorf("expected importing %q to be valid: %t, got %v", id, valid, err)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package bugsnag
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Secrets such as webhook signing secrets and integration API keys are
+// write-only arguments: Terraform (1.11 and later) passes them to the
+// provider but never stores them in the plan or state. Since there is
+// nothing in state to diff against, each one is paired with a
+// "<name>_version" argument, and the secret is only sent to the API when
+// the resource is created or that version changes.
+
+// writeOnlyVersionSuffix is appended to the name of a write-only argument to
+// get the name of its version argument.
+const writeOnlyVersionSuffix = "_version"
+
+// writeOnlySecretSchema returns the schemas of a write-only secret argument
+// called name and of its version argument, to be added to a resource schema.
+func writeOnlySecretSchema(name, description string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		name: {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			WriteOnly:   true,
+			Description: description + " This value is write-only: it is never stored in the plan or state, and requires Terraform 1.11 or later.",
+		},
+		name + writeOnlyVersionSuffix: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			RequiredWith: []string{name},
+			Description:  fmt.Sprintf("Change this value to send a new `%s` to Bugsnag.", name),
+		},
+	}
+}
+
+// getWriteOnlyString returns the configured value of a write-only argument,
+// which can only be read from the raw configuration.
+func getWriteOnlyString(d *schema.ResourceData, name string) (string, diag.Diagnostics) {
+	v, diags := d.GetRawConfigAt(cty.GetAttrPath(name))
+	if diags.HasError() {
+		return "", diags
+	}
+
+	if v.IsNull() || !v.IsKnown() {
+		return "", diags
+	}
+
+	return v.AsString(), diags
+}
+
+// writeOnlyChanged reports whether the write-only argument called name has
+// to be sent to the API, i.e. when the resource is being created or the
+// argument's version changed.
+func writeOnlyChanged(d *schema.ResourceData, name string) bool {
+	return d.IsNewResource() || d.HasChange(name+writeOnlyVersionSuffix)
+}
@@ -0,0 +1,250 @@
+package bugsnagtest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// RecordEnvVar is the environment variable that, when set, makes tests
+// record their cassettes against the real API instead of replaying them.
+const RecordEnvVar = "BUGSNAG_RECORD_CASSETTES"
+
+// Redacted replaces sensitive values in recorded cassettes.
+const Redacted = "REDACTED"
+
+// sensitiveFields are the JSON fields whose values are redacted from
+// request and response bodies before a cassette is saved.
+var sensitiveFields = map[string]bool{
+	"api_key":  true,
+	"token":    true,
+	"secret":   true,
+	"password": true,
+}
+
+// recordedHeaders are the only response headers kept in cassettes; the
+// others may identify the account or a session.
+var recordedHeaders = []string{"Content-Type", "Link", "ETag", "Retry-After", "X-Ratelimit-Limit", "X-Ratelimit-Remaining"}
+
+// Cassette is a recording of the interactions with the API made by a test.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and the response it received.
+type Interaction struct {
+	Method string `json:"method"`
+	// URL is the path and query of the request, so that cassettes replay
+	// against any host.
+	URL             string      `json:"url"`
+	RequestBody     string      `json:"request_body,omitempty"`
+	StatusCode      int         `json:"status_code"`
+	ResponseHeaders http.Header `json:"response_headers,omitempty"`
+	ResponseBody    string      `json:"response_body,omitempty"`
+
+	replayed bool
+}
+
+// Recorder is an http.RoundTripper that either records the interactions
+// sent through it to a cassette, or replays them from one without making
+// any request.
+//
+// When replaying, each request is answered with the first interaction not
+// yet replayed with the same method and URL, so that repeated reads see the
+// responses in the order they were recorded.
+type Recorder struct {
+	path      string
+	recording bool
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette *Cassette
+}
+
+// NewRecorder returns a Recorder for the cassette at path. It records with
+// transport when recording is true, replacing any existing cassette, and
+// replays the cassette otherwise.
+func NewRecorder(path string, recording bool, transport http.RoundTripper) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		recording: recording,
+		transport: transport,
+		cassette:  &Cassette{},
+	}
+	if recording {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, r.cassette); err != nil {
+		return nil, fmt.Errorf("reading cassette %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// Recording reports whether the recorder records interactions, rather than
+// replaying them.
+func (r *Recorder) Recording() bool {
+	return r.recording
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	if r.recording {
+		return r.record(req, body)
+	}
+	return r.replay(req)
+}
+
+func (r *Recorder) record(req *http.Request, requestBody []byte) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := readResponseBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := make(http.Header)
+	for _, name := range recordedHeaders {
+		if values := resp.Header.Values(name); len(values) > 0 {
+			headers[name] = values
+		}
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Method:          req.Method,
+		URL:             req.URL.RequestURI(),
+		RequestBody:     sanitize(requestBody),
+		StatusCode:      resp.StatusCode,
+		ResponseHeaders: headers,
+		ResponseBody:    sanitize(responseBody),
+	})
+	r.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, interaction := range r.cassette.Interactions {
+		if interaction.replayed || interaction.Method != req.Method || interaction.URL != req.URL.RequestURI() {
+			continue
+		}
+		interaction.replayed = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.StatusCode, http.StatusText(interaction.StatusCode)),
+			StatusCode:    interaction.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.ResponseHeaders.Clone(),
+			Body:          io.NopCloser(strings.NewReader(interaction.ResponseBody)),
+			ContentLength: int64(len(interaction.ResponseBody)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("cassette %s has no recorded interaction left for %s %s; re-record it with %s=1", r.path, req.Method, req.URL.RequestURI(), RecordEnvVar)
+}
+
+// Stop saves the cassette when recording. It does nothing when replaying.
+func (r *Recorder) Stop() error {
+	if !r.recording {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+// readResponseBody reads the body of resp, decompressing it so that the
+// cassette stays readable.
+func readResponseBody(resp *http.Response) ([]byte, error) {
+	defer resp.Body.Close()
+
+	var reader io.Reader = resp.Body
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		reader = gz
+
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+	}
+
+	return io.ReadAll(reader)
+}
+
+// sanitize redacts the values of sensitive fields from a JSON body. Bodies
+// that aren't JSON are kept as they are.
+func sanitize(body []byte) string {
+	var v interface{}
+	if len(body) == 0 || json.Unmarshal(body, &v) != nil {
+		return string(body)
+	}
+
+	sanitized, err := json.Marshal(redact(v))
+	if err != nil {
+		return string(body)
+	}
+	return string(sanitized)
+}
+
+func redact(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, value := range v {
+			if sensitiveFields[k] {
+				v[k] = Redacted
+			} else {
+				v[k] = redact(value)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redact(value)
+		}
+	}
+	return v
+}
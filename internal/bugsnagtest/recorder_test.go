@@ -0,0 +1,72 @@
+package bugsnagtest
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorderRecordsAndReplays(t *testing.T) {
+	server := NewServer()
+	project := server.AddProject(map[string]interface{}{"name": "api", "type": "go"})
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	url := server.URL + "/organizations/" + OrganizationID + "/projects/" + project["id"].(string)
+
+	recorder, err := NewRecorder(path, true, http.DefaultTransport)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	recorded := get(t, recorder, url)
+	if err := recorder.Stop(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	server.Close()
+
+	cassette, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if strings.Contains(string(cassette), project["api_key"].(string)) {
+		t.Fatalf("expected the api_key to be redacted from the cassette")
+	}
+
+	recorder, err = NewRecorder(path, false, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	replayed := get(t, recorder, url)
+	if !strings.Contains(replayed, `"name":"api"`) || !strings.Contains(replayed, Redacted) {
+		t.Fatalf("unexpected replayed response: %s", replayed)
+	}
+	if !strings.Contains(recorded, project["api_key"].(string)) {
+		t.Fatalf("expected the recorded response to reach the caller unredacted, got %s", recorded)
+	}
+
+	req, _ := http.NewRequest("GET", url, nil)
+	if _, err := recorder.RoundTrip(req); err == nil {
+		t.Fatalf("expected an error once the recorded interactions are used up")
+	}
+}
+
+func get(t *testing.T, transport http.RoundTripper, url string) string {
+	t.Helper()
+
+	req, _ := http.NewRequest("GET", url, nil)
+	req.Header.Set("Authorization", "token secret")
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	return string(body)
+}
@@ -0,0 +1,1078 @@
+// Package bugsnagtest provides a fake Bugsnag Data Access API, serving the
+// endpoints the provider uses from memory, for testing the client and the
+// provider without reaching the real API.
+package bugsnagtest
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// OrganizationID is the ID of the only organization the server knows.
+const OrganizationID = "515fb9337c1074f6fd000001"
+
+// projectFixture holds the attributes a project is created with, before the
+// ones given on creation.
+//
+//go:embed testdata/project.json
+var projectFixture []byte
+
+// Server is a fake Bugsnag API. Its zero value is not usable; create one
+// with NewServer.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	projects []map[string]interface{}
+	tokens   map[string]bool
+	settings map[string]map[string]interface{}
+	fields   map[string][]map[string]interface{}
+	// collaborators are kept in the order they were added
+	collaborators []map[string]interface{}
+	nextID        int
+	failures      map[string][]int
+	requests      []string
+
+	// teams hold the permission of each team, by project ID
+	teams   map[string]map[string]interface{}
+	domains []string
+	// sso is nil until the organization is configured for SSO
+	sso map[string]interface{}
+	// webhooks hold the webhooks of each project, secrets included
+	webhooks map[string][]map[string]interface{}
+}
+
+// NewServer starts a fake Bugsnag API with no projects. The caller must
+// call Close when done with it.
+func NewServer() *Server {
+	s := &Server{
+		tokens:   make(map[string]bool),
+		settings: make(map[string]map[string]interface{}),
+		fields:   make(map[string][]map[string]interface{}),
+		teams:    make(map[string]map[string]interface{}),
+		webhooks: make(map[string][]map[string]interface{}),
+		failures: make(map[string][]int),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// AddProject stores a project with the given attributes, filling in the
+// others from the fixture, and returns it.
+func (s *Server) AddProject(attributes map[string]interface{}) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addProject(attributes)
+}
+
+// Project returns the stored project with the given ID, or nil.
+func (s *Server) Project(id string) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, project := s.findProject(id); project != nil {
+		return copyObject(project)
+	}
+	return nil
+}
+
+// Setting returns the settings of the given name of a project, e.g.
+// "email_notifications", as last updated or by default.
+func (s *Server) Setting(projectID, name string) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return copyObject(s.setting(projectID, name))
+}
+
+// SetStability sets the current stability of a project, as computed by
+// Bugsnag from its sessions.
+func (s *Server) SetStability(projectID string, userStability, sessionStability float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.settings[projectID+"/stability"] = map[string]interface{}{
+		"user_stability":    userStability,
+		"session_stability": sessionStability,
+	}
+}
+
+// SetEventUsage sets the events a project received in the current billing
+// period, against its allocation.
+func (s *Server) SetEventUsage(projectID string, eventsUsed, eventQuota int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.settings[projectID+"/event_usage"] = map[string]interface{}{
+		"events_used": eventsUsed,
+		"event_quota": eventQuota,
+	}
+}
+
+// DeleteProject removes the project with the given ID, as if it was deleted
+// in the dashboard.
+func (s *Server) DeleteProject(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i, _ := s.findProject(id); i >= 0 {
+		s.projects = append(s.projects[:i], s.projects[i+1:]...)
+	}
+}
+
+// Fail makes the next requests to method and path respond with the given
+// statuses, one per request, instead of being served.
+func (s *Server) Fail(method, path string, statuses ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := method + " " + path
+	s.failures[key] = append(s.failures[key], statuses...)
+}
+
+// Requests returns the method and path of every request served so far, e.g.
+// "GET /organizations/515fb9337c1074f6fd000001/projects".
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := r.Method + " " + r.URL.Path
+	s.requests = append(s.requests, key)
+
+	if statuses := s.failures[key]; len(statuses) > 0 {
+		s.failures[key] = statuses[1:]
+		if statuses[0] == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+		}
+		writeError(w, statuses[0], http.StatusText(statuses[0]))
+		return
+	}
+
+	if r.Header.Get("Authorization") == "" {
+		writeError(w, http.StatusUnauthorized, "missing API token")
+		return
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.URL.Path == "/user/organizations" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, []map[string]interface{}{{"id": OrganizationID, "name": "Acme"}})
+	case len(segments) < 2 || segments[0] != "organizations" || segments[1] != OrganizationID:
+		writeError(w, http.StatusNotFound, "not found")
+	case len(segments) == 2 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"id": OrganizationID, "name": "Acme"})
+	case len(segments) >= 3 && segments[2] == "projects":
+		s.serveProjects(w, r, segments[3:])
+	case len(segments) >= 3 && segments[2] == "access_tokens":
+		s.serveAccessTokens(w, r, segments[3:])
+	case len(segments) >= 3 && segments[2] == "collaborators":
+		s.serveCollaborators(w, r, segments[3:])
+	case len(segments) >= 3 && segments[2] == "teams":
+		s.serveTeams(w, r, segments[3:])
+	case len(segments) >= 3 && segments[2] == "allowed_email_domains":
+		s.serveAllowedEmailDomains(w, r, segments[3:])
+	case len(segments) == 3 && segments[2] == "saml_configuration":
+		s.serveSSOConfiguration(w, r)
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *Server) serveProjects(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		s.listProjects(w, r)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		attributes, ok := readObject(w, r)
+		if !ok {
+			return
+		}
+		if name, _ := attributes["name"].(string); name == "" {
+			writeError(w, http.StatusBadRequest, "name can't be blank")
+			return
+		}
+		writeJSON(w, http.StatusCreated, s.addProject(attributes))
+	case len(segments) == 2 && segments[1] == "api_key" && r.Method == http.MethodDelete:
+		_, project := s.findProject(segments[0])
+		if project == nil {
+			writeError(w, http.StatusNotFound, "project not found")
+			return
+		}
+		s.nextID++
+		project["api_key"] = fmt.Sprintf("%032x", s.nextID)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"api_key": project["api_key"]})
+	case len(segments) >= 2 && segments[1] == "event_fields":
+		if _, project := s.findProject(segments[0]); project == nil {
+			writeError(w, http.StatusNotFound, "project not found")
+			return
+		}
+		s.serveEventFields(w, r, segments[0], segments[2:])
+	case len(segments) >= 2 && segments[1] == "webhooks":
+		if _, project := s.findProject(segments[0]); project == nil {
+			writeError(w, http.StatusNotFound, "project not found")
+			return
+		}
+		s.serveWebhooks(w, r, segments[0], segments[2:])
+	case len(segments) == 3 && segments[1] == "integrations" && segments[2] == "gitlab":
+		if _, project := s.findProject(segments[0]); project == nil {
+			writeError(w, http.StatusNotFound, "project not found")
+			return
+		}
+		s.serveGitLabIntegration(w, r, segments[0])
+	case len(segments) == 2 && segments[1] == "stability" && r.Method == http.MethodGet:
+		if _, project := s.findProject(segments[0]); project == nil {
+			writeError(w, http.StatusNotFound, "project not found")
+			return
+		}
+		stability, ok := s.settings[segments[0]+"/stability"]
+		if !ok {
+			// a project without sessions has no stability yet
+			stability = map[string]interface{}{"user_stability": nil, "session_stability": nil}
+		}
+		writeJSON(w, http.StatusOK, stability)
+	case len(segments) == 2 && segments[1] == "event_usage" && r.Method == http.MethodGet:
+		if _, project := s.findProject(segments[0]); project == nil {
+			writeError(w, http.StatusNotFound, "project not found")
+			return
+		}
+		usage, ok := s.settings[segments[0]+"/event_usage"]
+		if !ok {
+			// a new project has received no events, and has no allocation of
+			// its own
+			usage = map[string]interface{}{"events_used": 0, "event_quota": nil}
+		}
+		writeJSON(w, http.StatusOK, usage)
+	case len(segments) == 2 && settingDefaults[segments[1]] != nil:
+		if _, project := s.findProject(segments[0]); project == nil {
+			writeError(w, http.StatusNotFound, "project not found")
+			return
+		}
+		s.serveSetting(w, r, segments[0], segments[1])
+	case len(segments) == 1:
+		i, project := s.findProject(segments[0])
+		if project == nil {
+			writeError(w, http.StatusNotFound, "project not found")
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, project)
+		case http.MethodPatch:
+			attributes, ok := readObject(w, r)
+			if !ok {
+				return
+			}
+			for k, v := range normalize(attributes) {
+				project[k] = v
+			}
+			writeJSON(w, http.StatusOK, project)
+		case http.MethodDelete:
+			s.projects = append(s.projects[:i], s.projects[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// EventFields returns the custom event fields of a project.
+func (s *Server) EventFields(projectID string) []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fields := make([]map[string]interface{}, 0, len(s.fields[projectID]))
+	for _, field := range s.fields[projectID] {
+		fields = append(fields, copyObject(field))
+	}
+	return fields
+}
+
+func (s *Server) serveEventFields(w http.ResponseWriter, r *http.Request, projectID string, segments []string) {
+	fields := s.fields[projectID]
+
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		// the built-in fields come first
+		listed := []map[string]interface{}{{
+			"display_id":     "user.email",
+			"custom":         false,
+			"filter_options": map[string]interface{}{"name": "User email"},
+		}}
+		writeJSON(w, http.StatusOK, append(listed, fields...))
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		field, ok := readObject(w, r)
+		if !ok {
+			return
+		}
+		displayID, _ := field["display_id"].(string)
+		if displayID == "" {
+			writeError(w, http.StatusBadRequest, "display_id can't be blank")
+			return
+		}
+		for _, existing := range fields {
+			if existing["display_id"] == displayID {
+				writeError(w, http.StatusConflict, "display_id has already been taken")
+				return
+			}
+		}
+		field["custom"] = true
+		s.fields[projectID] = append(fields, field)
+		writeJSON(w, http.StatusCreated, field)
+	case len(segments) == 1:
+		i := -1
+		for j, field := range fields {
+			if field["display_id"] == segments[0] {
+				i = j
+			}
+		}
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "event field not found")
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPatch:
+			attributes, ok := readObject(w, r)
+			if !ok {
+				return
+			}
+			// whether a field is custom is read-only
+			delete(attributes, "custom")
+			for k, v := range attributes {
+				fields[i][k] = v
+			}
+			writeJSON(w, http.StatusOK, fields[i])
+		case http.MethodDelete:
+			s.fields[projectID] = append(fields[:i], fields[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// Webhooks returns the webhooks of a project, each with the secret it
+// signs its requests with, which the API never returns.
+func (s *Server) Webhooks(projectID string) []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	webhooks := make([]map[string]interface{}, 0, len(s.webhooks[projectID]))
+	for _, webhook := range s.webhooks[projectID] {
+		webhooks = append(webhooks, copyObject(webhook))
+	}
+	return webhooks
+}
+
+func (s *Server) serveWebhooks(w http.ResponseWriter, r *http.Request, projectID string, segments []string) {
+	// the secret is write-only
+	writeWebhook := func(status int, webhook map[string]interface{}) {
+		webhook = copyObject(webhook)
+		delete(webhook, "secret")
+		writeJSON(w, status, webhook)
+	}
+
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		attributes, ok := readObject(w, r)
+		if !ok {
+			return
+		}
+		if url, _ := attributes["url"].(string); url == "" {
+			writeError(w, http.StatusBadRequest, "url can't be blank")
+			return
+		}
+		s.nextID++
+		webhook := map[string]interface{}{
+			"id":         fmt.Sprintf("%024x", s.nextID),
+			"project_id": projectID,
+			"url":        attributes["url"],
+			"secret":     "",
+			"triggers":   attributes["triggers"],
+		}
+		if secret, ok := attributes["secret"].(string); ok {
+			webhook["secret"] = secret
+		}
+		s.webhooks[projectID] = append(s.webhooks[projectID], webhook)
+		writeWebhook(http.StatusCreated, webhook)
+	case len(segments) == 1:
+		i := slices.IndexFunc(s.webhooks[projectID], func(webhook map[string]interface{}) bool { return webhook["id"] == segments[0] })
+		if i < 0 {
+			writeError(w, http.StatusNotFound, "webhook not found")
+			return
+		}
+		webhook := s.webhooks[projectID][i]
+
+		switch r.Method {
+		case http.MethodGet:
+			writeWebhook(http.StatusOK, webhook)
+		case http.MethodPatch:
+			attributes, ok := readObject(w, r)
+			if !ok {
+				return
+			}
+			for _, k := range []string{"url", "secret", "triggers"} {
+				if v, ok := attributes[k]; ok {
+					webhook[k] = v
+				}
+			}
+			writeWebhook(http.StatusOK, webhook)
+		case http.MethodDelete:
+			s.webhooks[projectID] = slices.Delete(s.webhooks[projectID], i, i+1)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// GitLabIntegration returns the GitLab integration of a project, with the
+// token the API never returns, or nil if it has none.
+func (s *Server) GitLabIntegration(projectID string) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	integration, ok := s.settings[projectID+"/gitlab"]
+	if !ok {
+		return nil
+	}
+	return copyObject(integration)
+}
+
+func (s *Server) serveGitLabIntegration(w http.ResponseWriter, r *http.Request, projectID string) {
+	key := projectID + "/gitlab"
+	// the token is write-only
+	writeIntegration := func(integration map[string]interface{}) {
+		integration = copyObject(integration)
+		delete(integration, "token")
+		writeJSON(w, http.StatusOK, integration)
+	}
+
+	integration, ok := s.settings[key]
+	switch r.Method {
+	case http.MethodGet:
+		if !ok {
+			writeError(w, http.StatusNotFound, "GitLab integration not found")
+			return
+		}
+		writeIntegration(integration)
+	case http.MethodPut:
+		attributes, ok := readObject(w, r)
+		if !ok {
+			return
+		}
+		if path, _ := attributes["project_path"].(string); path == "" {
+			writeError(w, http.StatusBadRequest, "project_path can't be blank")
+			return
+		}
+		updated := map[string]interface{}{
+			"instance_url": attributes["instance_url"],
+			"project_path": attributes["project_path"],
+			"token":        integration["token"],
+		}
+		if token, ok := attributes["token"].(string); ok && token != "" {
+			updated["token"] = token
+		}
+		s.settings[key] = updated
+		writeIntegration(updated)
+	case http.MethodDelete:
+		if !ok {
+			writeError(w, http.StatusNotFound, "GitLab integration not found")
+			return
+		}
+		delete(s.settings, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// AddCollaborator stores a member of the organization with access to no
+// project, and returns it.
+func (s *Server) AddCollaborator(email string) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return copyObject(s.addCollaborator(email))
+}
+
+// Collaborators returns the members of the organization, each with the
+// project_ids of the projects it can access.
+func (s *Server) Collaborators() []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	collaborators := make([]map[string]interface{}, 0, len(s.collaborators))
+	for _, collaborator := range s.collaborators {
+		collaborators = append(collaborators, copyObject(collaborator))
+	}
+	return collaborators
+}
+
+// AcceptInvitation accepts the invitation of the collaborator with the
+// given ID, as if they followed the link in the invitation email.
+func (s *Server) AcceptInvitation(id, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if collaborator := s.findCollaborator("id", id); collaborator != nil {
+		collaborator["pending_invitation"] = false
+		collaborator["name"] = name
+	}
+}
+
+func (s *Server) addCollaborator(email string) map[string]interface{} {
+	s.nextID++
+	collaborator := map[string]interface{}{
+		"id":                 fmt.Sprintf("%024x", s.nextID),
+		"name":               "",
+		"email":              email,
+		"is_admin":           false,
+		"project_ids":        []interface{}{},
+		"pending_invitation": false,
+	}
+	s.collaborators = append(s.collaborators, collaborator)
+	return collaborator
+}
+
+func (s *Server) findCollaborator(key, value string) map[string]interface{} {
+	for _, collaborator := range s.collaborators {
+		if collaborator[key] == value {
+			return collaborator
+		}
+	}
+	return nil
+}
+
+func (s *Server) serveCollaborators(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, s.collaborators)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		attributes, ok := readObject(w, r)
+		if !ok {
+			return
+		}
+		projectIDs, _ := attributes["project_ids"].([]interface{})
+		emails, _ := attributes["emails"].([]interface{})
+		if email, ok := attributes["email"].(string); ok {
+			emails = []interface{}{email}
+		}
+		for _, email := range emails {
+			if email, _ := email.(string); !s.emailAllowed(email) {
+				writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("%s isn't of an allowed email domain", email))
+				return
+			}
+		}
+
+		// a single invitation, with the permissions of the collaborator
+		if email, ok := attributes["email"].(string); ok {
+			collaborator := s.inviteCollaborator(email, projectIDs)
+			if admin, ok := attributes["admin"].(bool); ok && admin {
+				collaborator["is_admin"] = true
+			}
+			writeJSON(w, http.StatusOK, collaborator)
+			return
+		}
+
+		invited := make([]map[string]interface{}, 0, len(emails))
+		for _, email := range emails {
+			email, _ := email.(string)
+			invited = append(invited, s.inviteCollaborator(email, projectIDs))
+		}
+		writeJSON(w, http.StatusOK, invited)
+	case len(segments) == 2 && segments[1] == "resend_invitation" && r.Method == http.MethodPost:
+		collaborator := s.findCollaborator("id", segments[0])
+		if collaborator == nil {
+			writeError(w, http.StatusNotFound, "collaborator not found")
+			return
+		}
+		if collaborator["pending_invitation"] != true {
+			writeError(w, http.StatusConflict, "invitation already accepted")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case len(segments) == 1:
+		collaborator := s.findCollaborator("id", segments[0])
+		if collaborator == nil {
+			writeError(w, http.StatusNotFound, "collaborator not found")
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, collaborator)
+		case http.MethodPatch:
+			attributes, ok := readObject(w, r)
+			if !ok {
+				return
+			}
+			if admin, ok := attributes["admin"].(bool); ok {
+				collaborator["is_admin"] = admin
+			}
+			if projectIDs, ok := attributes["project_ids"].([]interface{}); ok {
+				collaborator["project_ids"] = projectIDs
+			}
+			writeJSON(w, http.StatusOK, collaborator)
+		case http.MethodDelete:
+			s.collaborators = slices.DeleteFunc(s.collaborators, func(c map[string]interface{}) bool { return c["id"] == segments[0] })
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+	case len(segments) == 2 && segments[1] == "projects" && r.Method == http.MethodPatch:
+		collaborator := s.findCollaborator("id", segments[0])
+		if collaborator == nil {
+			writeError(w, http.StatusNotFound, "collaborator not found")
+			return
+		}
+		attributes, ok := readObject(w, r)
+		if !ok {
+			return
+		}
+		projectIDs, _ := attributes["add_project_ids"].([]interface{})
+		addProjectIDs(collaborator, projectIDs)
+		writeJSON(w, http.StatusOK, collaborator)
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// inviteCollaborator invites email to the organization, unless it is
+// already a member, and gives it access to projectIDs.
+func (s *Server) inviteCollaborator(email string, projectIDs []interface{}) map[string]interface{} {
+	collaborator := s.findCollaborator("email", email)
+	if collaborator == nil {
+		collaborator = s.addCollaborator(email)
+		collaborator["pending_invitation"] = true
+	}
+	addProjectIDs(collaborator, projectIDs)
+	return collaborator
+}
+
+func addProjectIDs(collaborator map[string]interface{}, projectIDs []interface{}) {
+	current := collaborator["project_ids"].([]interface{})
+	for _, id := range projectIDs {
+		if !slices.Contains(current, id) {
+			current = append(current, id)
+		}
+	}
+	collaborator["project_ids"] = current
+}
+
+// AddTeam stores a team with access to no project, and returns its ID.
+func (s *Server) AddTeam() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	id := fmt.Sprintf("%024x", s.nextID)
+	s.teams[id] = make(map[string]interface{})
+	return id
+}
+
+// TeamProjects returns the permission of a team on each project it can
+// access, by project ID.
+func (s *Server) TeamProjects(teamID string) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return copyObject(s.teams[teamID])
+}
+
+func (s *Server) serveTeams(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) != 3 || segments[1] != "projects" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	teamID, projectID := segments[0], segments[2]
+	projects, ok := s.teams[teamID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "team not found")
+		return
+	}
+	access := func() map[string]interface{} {
+		return map[string]interface{}{
+			"team_id":    teamID,
+			"project_id": projectID,
+			"permission": projects[projectID],
+		}
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if _, ok := projects[projectID]; !ok {
+			writeError(w, http.StatusNotFound, "team can't access the project")
+			return
+		}
+		writeJSON(w, http.StatusOK, access())
+	case http.MethodPut:
+		if _, project := s.findProject(projectID); project == nil {
+			writeError(w, http.StatusNotFound, "project not found")
+			return
+		}
+		attributes, ok := readObject(w, r)
+		if !ok {
+			return
+		}
+		permission, _ := attributes["permission"].(string)
+		if permission != "member" && permission != "admin" {
+			writeError(w, http.StatusBadRequest, "invalid permission")
+			return
+		}
+		projects[projectID] = permission
+		writeJSON(w, http.StatusOK, access())
+	case http.MethodDelete:
+		if _, ok := projects[projectID]; !ok {
+			writeError(w, http.StatusNotFound, "team can't access the project")
+			return
+		}
+		delete(projects, projectID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// AllowedEmailDomains returns the email domains people can be invited to
+// the organization with.
+func (s *Server) AllowedEmailDomains() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.domains)
+}
+
+// emailAllowed reports whether people can be invited with email: any email
+// until the organization allows some domains, then only those of them.
+func (s *Server) emailAllowed(email string) bool {
+	_, domain, _ := strings.Cut(email, "@")
+	return len(s.domains) == 0 || slices.Contains(s.domains, strings.ToLower(domain))
+}
+
+func (s *Server) serveAllowedEmailDomains(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		domains := make([]map[string]interface{}, 0, len(s.domains))
+		for _, domain := range s.domains {
+			domains = append(domains, map[string]interface{}{"domain": domain})
+		}
+		writeJSON(w, http.StatusOK, domains)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		attributes, ok := readObject(w, r)
+		if !ok {
+			return
+		}
+		domain, _ := attributes["domain"].(string)
+		if domain == "" {
+			writeError(w, http.StatusBadRequest, "domain can't be blank")
+			return
+		}
+		if !slices.Contains(s.domains, domain) {
+			s.domains = append(s.domains, domain)
+		}
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"domain": domain})
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		if !slices.Contains(s.domains, segments[0]) {
+			writeError(w, http.StatusNotFound, "domain not found")
+			return
+		}
+		s.domains = slices.DeleteFunc(s.domains, func(domain string) bool { return domain == segments[0] })
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// SSOConfiguration returns the SSO configuration of the organization, or nil
+// if it doesn't use SSO.
+func (s *Server) SSOConfiguration() map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.sso == nil {
+		return nil
+	}
+	return copyObject(s.sso)
+}
+
+func (s *Server) serveSSOConfiguration(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		if s.sso == nil {
+			writeError(w, http.StatusNotFound, "SSO isn't configured")
+			return
+		}
+		writeJSON(w, http.StatusOK, s.sso)
+	case http.MethodPut:
+		attributes, ok := readObject(w, r)
+		if !ok {
+			return
+		}
+		metadataURL, _ := attributes["idp_metadata_url"].(string)
+		if !strings.HasPrefix(metadataURL, "https://") {
+			writeError(w, http.StatusBadRequest, "idp_metadata_url must be an https URL")
+			return
+		}
+		role, _ := attributes["default_role"].(string)
+		if role == "" {
+			role = "collaborator"
+		}
+		if role != "collaborator" && role != "admin" {
+			writeError(w, http.StatusBadRequest, "invalid default_role")
+			return
+		}
+		enforced, _ := attributes["enforced"].(bool)
+		s.sso = map[string]interface{}{
+			"idp_metadata_url": metadataURL,
+			"enforced":         enforced,
+			"default_role":     role,
+			"entity_id":        fmt.Sprintf("https://app.bugsnag.com/saml/%s", OrganizationID),
+			"acs_url":          fmt.Sprintf("https://app.bugsnag.com/saml/%s/consume", OrganizationID),
+		}
+		writeJSON(w, http.StatusOK, s.sso)
+	case http.MethodDelete:
+		if s.sso == nil {
+			writeError(w, http.StatusNotFound, "SSO isn't configured")
+			return
+		}
+		s.sso = nil
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// settingDefaults are the settings served under a project, e.g. at
+// /projects/{id}/email_notifications, with the values every project starts
+// with.
+var settingDefaults = map[string]map[string]interface{}{
+	"email_notifications": {
+		"new_errors":      true,
+		"reopened_errors": true,
+		"error_spikes":    true,
+		"daily_summary":   false,
+	},
+	"reopen_rules": {
+		"enabled":     false,
+		"occurrences": 10,
+		"hours":       24,
+	},
+	"spike_detection": {
+		"enabled":              true,
+		"threshold_multiplier": 4.0,
+		"minimum_events":       10,
+		"window_minutes":       60,
+		"notify_integrations":  true,
+	},
+	"stability_targets": {
+		"target_stability":   99.5,
+		"critical_stability": 98.0,
+	},
+}
+
+func (s *Server) serveSetting(w http.ResponseWriter, r *http.Request, projectID, name string) {
+	setting := s.setting(projectID, name)
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, setting)
+	case http.MethodPatch:
+		attributes, ok := readObject(w, r)
+		if !ok {
+			return
+		}
+		for k, v := range attributes {
+			setting[k] = v
+		}
+		writeJSON(w, http.StatusOK, setting)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) setting(projectID, name string) map[string]interface{} {
+	key := projectID + "/" + name
+	if _, ok := s.settings[key]; !ok {
+		s.settings[key] = copyObject(settingDefaults[name])
+	}
+	return s.settings[key]
+}
+
+// listProjects serves a page of the project list, linking to the next page
+// in the manner of the real API.
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request) {
+	perPage, err := strconv.Atoi(r.URL.Query().Get("per_page"))
+	if err != nil || perPage <= 0 {
+		perPage = 30
+	}
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	// q filters by name, partially and regardless of case
+	projects := s.projects
+	if q := strings.ToLower(r.URL.Query().Get("q")); q != "" {
+		projects = nil
+		for _, project := range s.projects {
+			if name, _ := project["name"].(string); strings.Contains(strings.ToLower(name), q) {
+				projects = append(projects, project)
+			}
+		}
+	}
+
+	page := make([]map[string]interface{}, 0, perPage)
+	for i := offset; i < len(projects) && i < offset+perPage; i++ {
+		page = append(page, projects[i])
+	}
+
+	if offset+perPage < len(projects) {
+		next := r.URL.Query()
+		next.Set("offset", strconv.Itoa(offset+perPage))
+		next.Set("per_page", strconv.Itoa(perPage))
+		w.Header().Set("Link", fmt.Sprintf(`<%s?%s>; rel="next"`, r.URL.Path, next.Encode()))
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+func (s *Server) serveAccessTokens(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		if _, ok := readObject(w, r); !ok {
+			return
+		}
+		s.nextID++
+		id := fmt.Sprintf("t%d", s.nextID)
+		s.tokens[id] = true
+		writeJSON(w, http.StatusCreated, map[string]interface{}{
+			"id":         id,
+			"token":      "secret-" + id,
+			"expires_at": "2030-01-01T00:00:00Z",
+		})
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		if !s.tokens[segments[0]] {
+			writeError(w, http.StatusNotFound, "access token not found")
+			return
+		}
+		delete(s.tokens, segments[0])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (s *Server) addProject(attributes map[string]interface{}) map[string]interface{} {
+	project := make(map[string]interface{})
+	if err := json.Unmarshal(projectFixture, &project); err != nil {
+		panic(fmt.Sprintf("bugsnagtest: invalid project fixture: %s", err))
+	}
+
+	s.nextID++
+	id := fmt.Sprintf("%024x", s.nextID)
+	project["id"] = id
+	project["organization_id"] = OrganizationID
+	project["url"] = fmt.Sprintf("%s/projects/%s", s.URL, id)
+	project["errors_url"] = fmt.Sprintf("%s/projects/%s/errors", s.URL, id)
+	project["events_url"] = fmt.Sprintf("%s/projects/%s/events", s.URL, id)
+
+	for k, v := range normalize(attributes) {
+		// settings given on creation are served apart from the project
+		if settings, ok := v.(map[string]interface{}); ok && settingDefaults[k] != nil {
+			setting := s.setting(id, k)
+			for k, v := range settings {
+				setting[k] = v
+			}
+			continue
+		}
+		project[k] = v
+	}
+
+	name, _ := project["name"].(string)
+	if project["slug"] == "" {
+		project["slug"] = strings.ReplaceAll(strings.ToLower(name), " ", "-")
+	}
+	if project["html_url"] == "" {
+		project["html_url"] = fmt.Sprintf("https://app.bugsnag.com/acme/%s", project["slug"])
+	}
+	if project["language"] == "" {
+		project["language"] = project["type"]
+	}
+
+	s.projects = append(s.projects, project)
+	return copyObject(project)
+}
+
+// normalize changes project attributes the way the real API does: URL
+// whitelist entries are lowercased, without surrounding whitespace or
+// trailing slashes.
+func normalize(attributes map[string]interface{}) map[string]interface{} {
+	if entries, ok := attributes["url_whitelist"].([]interface{}); ok {
+		normalized := make([]interface{}, 0, len(entries))
+		for _, entry := range entries {
+			if entry, ok := entry.(string); ok {
+				normalized = append(normalized, strings.TrimRight(strings.ToLower(strings.TrimSpace(entry)), "/"))
+			}
+		}
+		attributes["url_whitelist"] = normalized
+	}
+	return attributes
+}
+
+func (s *Server) findProject(id string) (int, map[string]interface{}) {
+	for i, project := range s.projects {
+		if project["id"] == id {
+			return i, project
+		}
+	}
+	return -1, nil
+}
+
+// readObject decodes the JSON object in the request body, responding with a
+// 400 if it isn't one.
+func readObject(w http.ResponseWriter, r *http.Request) (map[string]interface{}, bool) {
+	attributes := make(map[string]interface{})
+	if err := json.NewDecoder(r.Body).Decode(&attributes); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid JSON body: %s", err))
+		return nil, false
+	}
+	return attributes, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError responds in the format of the real API's errors.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{"errors": []string{message}})
+}
+
+func copyObject(object map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(object))
+	for k, v := range object {
+		copied[k] = v
+	}
+	return copied
+}
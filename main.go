@@ -5,8 +5,8 @@ import (
 	"flag"
 	"log"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
-	"github.com/hashicorp/terraform-provider-bugsnag/internal/provider"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
+	"github.com/hashicorp/terraform-provider-bugsnag/internal/bugsnag"
 )
 
 // Run "go generate" to format example terraform files and generate the docs for the registry/website
@@ -17,7 +17,7 @@ import (
 
 // Run the docs generation tool, check its repository for more information on how it works and how docs
 // can be customized.
-//go:generate go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs
+//go:generate go run -modfile=tools/go.mod github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs generate --provider-name bugsnag
 
 var (
 	// these will be set by the goreleaser configuration
@@ -28,22 +28,35 @@ var (
 	// commit  string = ""
 )
 
+// providerAddr is the provider's source address as used in required_providers
+// blocks. Terraform only reattaches to a debugged provider whose address
+// matches the one in TF_REATTACH_PROVIDERS.
+const providerAddr = "hashicorp.com/edu/bugsnag"
+
 func main() {
 	var debugMode bool
 
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := &plugin.ServeOpts{ProviderFunc: provider.New(version)}
+	ctx := context.Background()
+
+	// the SDKv2 and plugin framework providers are served together over
+	// protocol version 6
+	muxServer, err := bugsnag.NewMuxServer(ctx, version)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
 
+	var serveOpts []tf6server.ServeOpt
 	if debugMode {
-		// TODO: update this string with the full name of your provider as used in your configs
-		err := plugin.Debug(context.Background(), "registry.terraform.io/hashicorp/bugsnag", opts)
-		if err != nil {
-			log.Fatal(err.Error())
-		}
-		return
+		// prints the TF_REATTACH_PROVIDERS value to export before running
+		// Terraform, and serves until interrupted
+		serveOpts = append(serveOpts, tf6server.WithManagedDebug())
 	}
 
-	plugin.Serve(opts)
+	err = tf6server.Serve(providerAddr, muxServer, serveOpts...)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
 }
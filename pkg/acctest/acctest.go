@@ -0,0 +1,158 @@
+// Package acctest provides helpers for writing Terraform acceptance tests
+// against the Bugsnag provider, e.g. for modules built on it:
+//
+//	resource.Test(t, resource.TestCase{
+//		PreCheck:                 func() { acctest.PreCheck(t) },
+//		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+//		CheckDestroy:             acctest.CheckProjectDestroyed,
+//		Steps: []resource.TestStep{
+//			{
+//				Config: config,
+//				Check:  acctest.CheckProjectExists("bugsnag_project.test"),
+//			},
+//		},
+//	})
+package acctest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-bugsnag/internal/bugsnag"
+	"github.com/hashicorp/terraform-provider-bugsnag/internal/bugsnagtest"
+	api "github.com/hashicorp/terraform-provider-bugsnag/pkg/bugsnag"
+)
+
+// MockServerEnvVar is the environment variable that, when set, runs the
+// acceptance tests against a bundled mock of the Bugsnag API instead of the
+// real one.
+const MockServerEnvVar = "BUGSNAG_ACC_MOCK"
+
+// ProtoV6ProviderFactories instantiate the provider for resource.TestCase.
+// The factory function will be invoked for every Terraform CLI command
+// executed to create a provider server to which the CLI can reattach.
+var ProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"bugsnag": func() (tfprotov6.ProviderServer, error) {
+		muxServer, err := bugsnag.NewMuxServer(context.Background(), "acctest", bugsnag.WithTransport(cassetteTransport))
+		if err != nil {
+			return nil, err
+		}
+		return muxServer(), nil
+	},
+}
+
+// cassetteTransport records or replays the API interactions of the running
+// test, when it uses a cassette; see UseCassette.
+var cassetteTransport http.RoundTripper
+
+// PreCheck points the provider at the API the acceptance test runs against:
+// the mock server when BUGSNAG_ACC_MOCK is set, or else the real API,
+// through the test's cassette if it has one (see UseCassette).
+func PreCheck(t *testing.T) {
+	if os.Getenv(MockServerEnvVar) != "" {
+		server := bugsnagtest.NewServer()
+		t.Cleanup(server.Close)
+
+		t.Setenv("BUGSNAG_BASE_URL", server.URL)
+		t.Setenv("BUGSNAG_API_TOKEN", "mock-token")
+		t.Setenv("BUGSNAG_ORGANIZATION_ID", bugsnagtest.OrganizationID)
+		return
+	}
+
+	UseCassette(t)
+
+	if os.Getenv("BUGSNAG_API_TOKEN") == "" && os.Getenv("BUGSNAG_API_TOKEN_FILE") == "" {
+		t.Fatalf("BUGSNAG_API_TOKEN must be set for acceptance tests, or set %s=1 to run them against a mock server", MockServerEnvVar)
+	}
+}
+
+// UseCassette records the API interactions of the test to
+// testdata/cassettes when BUGSNAG_RECORD_CASSETTES is set, or replays them
+// from there if the test has a cassette. Otherwise the test reaches the
+// real API. API keys and tokens are redacted from recorded cassettes.
+func UseCassette(t *testing.T) {
+	path := filepath.Join("testdata", "cassettes", strings.ReplaceAll(t.Name(), "/", "_")+".json")
+
+	recording := os.Getenv(bugsnagtest.RecordEnvVar) != ""
+	if _, err := os.Stat(path); !recording && os.IsNotExist(err) {
+		return
+	}
+
+	recorder, err := bugsnagtest.NewRecorder(path, recording, http.DefaultTransport)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !recording {
+		// the recorded responses don't depend on the credentials
+		t.Setenv("BUGSNAG_API_TOKEN", "replayed-token")
+	}
+
+	cassetteTransport = recorder
+	t.Cleanup(func() {
+		cassetteTransport = nil
+		if err := recorder.Stop(); err != nil {
+			t.Errorf("saving the cassette: %s", err)
+		}
+	})
+}
+
+// Client returns a client for the API the acceptance test runs against, to
+// check on the objects it created.
+func Client() *api.Client {
+	baseURL := os.Getenv("BUGSNAG_BASE_URL")
+	if baseURL == "" {
+		baseURL = api.DefaultBaseURL
+	}
+
+	return api.NewClient(api.ClientConfig{
+		BaseURL:        baseURL,
+		APIToken:       os.Getenv("BUGSNAG_API_TOKEN"),
+		OrganizationID: os.Getenv("BUGSNAG_ORGANIZATION_ID"),
+		Transport:      cassetteTransport,
+	})
+}
+
+// CheckProjectExists checks that the project in the state under name, e.g.
+// "bugsnag_project.test", exists in Bugsnag.
+func CheckProjectExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("%s not found in the state", name)
+		}
+
+		_, err := Client().GetProject(context.Background(), rs.Primary.ID)
+		return err
+	}
+}
+
+// CheckProjectDestroyed checks that every project in the state was deleted
+// from Bugsnag. It is meant for resource.TestCase's CheckDestroy.
+func CheckProjectDestroyed(s *terraform.State) error {
+	c := Client()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bugsnag_project" {
+			continue
+		}
+
+		_, err := c.GetProject(context.Background(), rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("project %s still exists", rs.Primary.ID)
+		}
+		if !errors.Is(err, api.ErrNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package bugsnag
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// circuitBreakerCooldown is how long an open circuit fails requests fast
+// before letting a single request through to probe the API again.
+const circuitBreakerCooldown = 30 * time.Second
+
+// circuitBreaker stops the client from sending requests once the API has
+// failed several requests in a row, so a large refresh against an API outage
+// fails fast instead of sending hundreds of doomed requests.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	failures  int
+	openedAt  time.Time
+}
+
+// newCircuitBreaker returns a breaker opening after threshold consecutive
+// failures, or nil (never open) when threshold is not positive.
+func newCircuitBreaker(threshold int) *circuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &circuitBreaker{threshold: threshold}
+}
+
+// allow returns an error when the circuit is open.
+func (b *circuitBreaker) allow(now time.Time) error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return nil
+	}
+
+	if now.Sub(b.openedAt) >= circuitBreakerCooldown {
+		// half-open: let this request probe the API, and re-open the
+		// circuit for another cooldown if it fails too
+		b.openedAt = now
+		return nil
+	}
+
+	return fmt.Errorf(`the Bugsnag API failed %d consecutive requests, so further requests are being failed fast until %s.
+Please check https://status.bugsnag.com and try again later`, b.failures, b.openedAt.Add(circuitBreakerCooldown).UTC().Format(time.RFC3339))
+}
+
+// record counts the outcome of a request.
+func (b *circuitBreaker) record(failed bool, now time.Time) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !failed {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures == b.threshold {
+		b.openedAt = now
+	}
+}
@@ -0,0 +1,40 @@
+package bugsnag
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	b := newCircuitBreaker(2)
+	now := time.Now()
+
+	b.record(true, now)
+	if err := b.allow(now); err != nil {
+		t.Fatalf("expected the circuit to stay closed after 1 failure, got %s", err)
+	}
+
+	b.record(true, now)
+	if err := b.allow(now); err == nil {
+		t.Fatalf("expected the circuit to open after 2 consecutive failures")
+	}
+
+	probe := now.Add(circuitBreakerCooldown)
+	if err := b.allow(probe); err != nil {
+		t.Fatalf("expected a probe request to be allowed after the cooldown, got %s", err)
+	}
+	if err := b.allow(probe); err == nil {
+		t.Fatalf("expected only a single probe request to be allowed")
+	}
+
+	b.record(false, probe)
+	if err := b.allow(probe); err != nil {
+		t.Fatalf("expected a successful request to close the circuit, got %s", err)
+	}
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	if b := newCircuitBreaker(0); b != nil {
+		t.Fatalf("expected no circuit breaker when the threshold is 0")
+	}
+}
@@ -0,0 +1,1013 @@
+// Package bugsnag is a client for the Bugsnag Data Access API.
+//
+// The client retries rate limited requests and transient server errors,
+// follows pagination, and returns errors wrapping ErrNotFound,
+// ErrUnauthorized, ErrRateLimited or ErrConflict for failed requests.
+package bugsnag
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"iter"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBaseURL is the Bugsnag API host used when no BaseURL is
+// configured.
+const DefaultBaseURL string = "https://api.bugsnag.com"
+
+// RegionBaseURLs maps each data residency region to the API host serving
+// it.
+var RegionBaseURLs = map[string]string{
+	"us": DefaultBaseURL,
+	"eu": "https://api.eu.bugsnag.com",
+}
+
+// DefaultAuthType is the type of APIToken when AuthType is not configured.
+const DefaultAuthType = "api_token"
+
+// authSchemes maps each AuthType to the scheme used in the Authorization
+// header.
+var authSchemes = map[string]string{
+	"api_token":           "token",
+	"personal_auth_token": "Bearer",
+}
+
+// DefaultAPIVersion is the Bugsnag API version requested through the
+// X-Version header unless APIVersion overrides it.
+const DefaultAPIVersion = "2"
+
+// Transport defaults. All requests go to a single host, so idle connections
+// are pooled per host up to DefaultMaxIdleConns, which matches Terraform's
+// default -parallelism.
+const (
+	DefaultMaxIdleConns    = 10
+	DefaultIdleConnTimeout = 90 * time.Second
+	DefaultKeepAlive       = 30 * time.Second
+)
+
+// DefaultRequestTimeout bounds each request when no RequestTimeout is
+// configured.
+const DefaultRequestTimeout = 10 * time.Second
+
+// DefaultMaxRateLimitRetries is the number of times a rate limited request
+// is retried when MaxRateLimitRetries is not configured.
+const DefaultMaxRateLimitRetries = 3
+
+// DefaultMaxRetries is the number of times a request that failed with a
+// transient error is retried when MaxRetries is not configured.
+const DefaultMaxRetries = 5
+
+// DefaultMaxRetryElapsedTime bounds the time spent retrying a request when
+// MaxRetryElapsedTime is not configured.
+const DefaultMaxRetryElapsedTime = 2 * time.Minute
+
+// Client is a Bugsnag Data Access API client. It is safe for concurrent
+// use.
+type Client struct {
+	BaseURL             string
+	HostURL             string
+	HTTPClient          *http.Client
+	OrganizationID      string
+	APIToken            string
+	AuthType            string
+	RequestTimeout      time.Duration
+	MaxRateLimitRetries int
+	MaxRetries          int
+	MaxRetryElapsedTime time.Duration
+	limiter             *rateLimiter
+	rateLimit           *rateLimitTracker
+	breaker             *circuitBreaker
+	etags               *etagCache
+	flights             *requestGroup
+	maxResponseSize     int64
+	usage               *usageTracker
+
+	// ValidateCredentials makes the first API operation check that the
+	// token can access the organization before doing anything else.
+	ValidateCredentials bool
+	initMu              sync.Mutex
+	initialized         bool
+
+	// CustomHeaders are sent with every request, e.g. for a gateway in front
+	// of Bugsnag On-premise.
+	CustomHeaders map[string]string
+
+	UserAgent  string
+	APIVersion string
+
+	tracer *tracer
+}
+
+// ClientConfig configures a Client. Only APIToken is required; the
+// organization is discovered when OrganizationID is empty.
+type ClientConfig struct {
+	BaseURL             string
+	APIToken            string
+	AuthType            string
+	OrganizationID      string
+	RequestTimeout      time.Duration
+	TLSConfig           *tls.Config
+	MaxRateLimitRetries int
+	RequestsPerMinute   int
+	MaxResponseSize     int64
+
+	// MaxRetries is the number of times a request that failed with a
+	// transient error (a 502, 503 or 504 response, or a network error) is
+	// retried, if it is safe to send again. Zero disables these retries.
+	MaxRetries int
+
+	// MaxRetryElapsedTime bounds the time spent retrying a request, both
+	// after transient errors and rate limiting. Once a wait would exceed it,
+	// the last failure is returned. Defaults to DefaultMaxRetryElapsedTime.
+	MaxRetryElapsedTime time.Duration
+
+	MaxIdleConns    int
+	IdleConnTimeout time.Duration
+	KeepAlive       time.Duration
+
+	// Transport, when set, sends the client's requests instead of a pooled
+	// transport built from TLSConfig and the connection settings above, e.g.
+	// to serve canned responses in tests.
+	Transport http.RoundTripper
+
+	// CircuitBreakerThreshold is the number of consecutive failed requests
+	// after which requests fail fast. Zero disables the circuit breaker.
+	CircuitBreakerThreshold int
+
+	// UsageSummary counts the requests sent, for Client.UsageSummary.
+	UsageSummary bool
+
+	// RateLimitWarningThreshold is the remaining quota at or below which
+	// RateLimitWarning reports the quota. Zero disables the warning.
+	RateLimitWarningThreshold int
+	ValidateCredentials       bool
+	CustomHeaders             map[string]string
+	UserAgent                 string
+	APIVersion                string
+
+	// OTLPEndpoint is the OTLP/HTTP collector that spans for each client
+	// operation are exported to. Tracing is disabled when it is empty.
+	OTLPEndpoint    string
+	ProviderVersion string
+}
+
+// NewClient returns a client for the given configuration. It doesn't
+// contact the API: the organization is discovered and the credentials are
+// validated on the first request.
+func NewClient(config ClientConfig) *Client {
+	timeout := config.RequestTimeout
+	if timeout <= 0 {
+		timeout = DefaultRequestTimeout
+	}
+
+	transport := config.Transport
+	if transport == nil {
+		transport = newTransport(config)
+	}
+
+	httpClient := &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+
+	authType := config.AuthType
+	if authType == "" {
+		authType = DefaultAuthType
+	}
+
+	apiVersion := config.APIVersion
+	if apiVersion == "" {
+		apiVersion = DefaultAPIVersion
+	}
+
+	maxRetryElapsedTime := config.MaxRetryElapsedTime
+	if maxRetryElapsedTime <= 0 {
+		maxRetryElapsedTime = DefaultMaxRetryElapsedTime
+	}
+
+	maxResponseSize := config.MaxResponseSize
+	if maxResponseSize <= 0 {
+		maxResponseSize = DefaultMaxResponseSize
+	}
+
+	rateLimit := newRateLimitTracker(config.RateLimitWarningThreshold)
+
+	c := &Client{
+		HTTPClient:          httpClient,
+		BaseURL:             strings.TrimSuffix(config.BaseURL, "/"),
+		OrganizationID:      config.OrganizationID,
+		APIToken:            config.APIToken,
+		AuthType:            authType,
+		RequestTimeout:      timeout,
+		MaxRateLimitRetries: config.MaxRateLimitRetries,
+		MaxRetries:          config.MaxRetries,
+		MaxRetryElapsedTime: maxRetryElapsedTime,
+		limiter:             newRateLimiter(config.RequestsPerMinute),
+		rateLimit:           rateLimit,
+		breaker:             newCircuitBreaker(config.CircuitBreakerThreshold),
+		etags:               newETagCache(),
+		flights:             newRequestGroup(),
+		maxResponseSize:     maxResponseSize,
+		usage:               newUsageTracker(config.UsageSummary, rateLimit),
+		ValidateCredentials: config.ValidateCredentials,
+		CustomHeaders:       config.CustomHeaders,
+		UserAgent:           config.UserAgent,
+		APIVersion:          apiVersion,
+		tracer:              newTracer(config.OTLPEndpoint, config.ProviderVersion),
+	}
+	c.setOrganizationID(config.OrganizationID)
+
+	return c
+}
+
+func (c *Client) setOrganizationID(organizationID string) {
+	c.OrganizationID = organizationID
+	c.HostURL = fmt.Sprintf("%s/organizations/%s", c.BaseURL, organizationID)
+}
+
+// newTransport returns the pooled transport shared by every request the
+// client sends.
+func newTransport(config ClientConfig) *http.Transport {
+	maxIdleConns := config.MaxIdleConns
+	if maxIdleConns <= 0 {
+		maxIdleConns = DefaultMaxIdleConns
+	}
+
+	idleConnTimeout := config.IdleConnTimeout
+	if idleConnTimeout <= 0 {
+		idleConnTimeout = DefaultIdleConnTimeout
+	}
+
+	keepAlive := config.KeepAlive
+	if keepAlive == 0 {
+		keepAlive = DefaultKeepAlive
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: keepAlive,
+	}).DialContext
+	transport.MaxIdleConns = maxIdleConns
+	transport.MaxIdleConnsPerHost = maxIdleConns
+	transport.IdleConnTimeout = idleConnTimeout
+	transport.TLSClientConfig = config.TLSConfig
+
+	// responses are decompressed by the client itself, see doRequest
+	transport.DisableCompression = true
+
+	return transport
+}
+
+// NewTLSConfig builds the TLS configuration for the client transport. It
+// returns nil when the defaults are sufficient.
+func NewTLSConfig(caCertFile string, insecureSkipVerify bool) (*tls.Config, error) {
+	if caCertFile == "" && !insecureSkipVerify {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: insecureSkipVerify}
+
+	if caCertFile != "" {
+		pem, err := ioutil.ReadFile(caCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA certificate file %s: %w", caCertFile, err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM encoded certificates found in %s", caCertFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+// operationContext returns the context bounding a single client operation,
+// which may span more than one HTTP request. It is derived from the caller's
+// context, so cancelling that context aborts the operation, and its deadline
+// leaves room for every allowed attempt and the waits between them. The
+// returned function must be called once the operation is done.
+func (c *Client) operationContext(ctx context.Context, name string) (context.Context, func()) {
+	options := c.requestOptions(ctx)
+	attempts := time.Duration(options.MaxRateLimitRetries + options.MaxRetries + 1)
+	timeout := attempts*options.RequestTimeout + options.MaxRetryElapsedTime
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	ctx, span := c.tracer.start(ctx, "bugsnag."+name, spanKindInternal)
+
+	return ctx, func() {
+		span.finish()
+		cancel()
+	}
+}
+
+func (c *Client) doRequest(req *http.Request) (*http.Response, error) {
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	req.Header.Set("X-Version", c.APIVersion)
+	// large project lists compress well; see decompressBody
+	req.Header.Set("Accept-Encoding", "gzip")
+	for k, v := range c.CustomHeaders {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("%s %s", authSchemes[c.AuthType], c.APIToken))
+	c.etags.prepare(req)
+
+	if err := c.breaker.allow(time.Now()); err != nil {
+		return nil, err
+	}
+
+	r, err := c.doRequestWithRetries(req)
+
+	// cancellation by the caller says nothing about the API's health
+	failed := (err != nil && req.Context().Err() == nil) || (err == nil && r.StatusCode >= 500)
+	c.breaker.record(failed, time.Now())
+
+	if err != nil {
+		return nil, err
+	}
+	return c.etags.update(req, r)
+}
+
+// doRequestWithRetries sends the request, retrying rate limited requests and
+// transient server errors.
+func (c *Client) doRequestWithRetries(req *http.Request) (*http.Response, error) {
+	options := c.requestOptions(req.Context())
+	started := time.Now()
+	rateLimitRetries, transientRetries := 0, 0
+	for {
+		if err := c.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		r, err := c.send(req)
+		if err != nil {
+			// the request may have reached the API before failing, so it is
+			// only sent again when that is safe
+			if req.Context().Err() != nil || !isIdempotent(req) {
+				return nil, err
+			}
+			wait := backoff(transientRetries)
+			if transientRetries >= options.MaxRetries || time.Since(started)+wait > options.MaxRetryElapsedTime {
+				return nil, err
+			}
+			transientRetries++
+
+			if err := sleepContext(req.Context(), wait); err != nil {
+				return nil, err
+			}
+			if err := rewindBody(req); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		c.rateLimit.observe(r.Header)
+
+		var wait time.Duration
+		switch {
+		case r.StatusCode == 429 && rateLimitRetries < options.MaxRateLimitRetries:
+			rateLimitRetries++
+			wait = parseRetryAfter(r.Header.Get("Retry-After"), time.Now())
+		case isTransientStatus(r.StatusCode) && isIdempotent(req) && transientRetries < options.MaxRetries:
+			transientRetries++
+			wait = backoff(transientRetries - 1)
+		default:
+			return r, nil
+		}
+
+		if time.Since(started)+wait > options.MaxRetryElapsedTime {
+			return r, nil
+		}
+
+		r.Body.Close()
+
+		if err := sleepContext(req.Context(), wait); err != nil {
+			return nil, err
+		}
+
+		if err := rewindBody(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// send performs a single HTTP request, recording it as a span of the
+// operation in the request's context.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	operation := spanFromContext(req.Context())
+	_, span := c.tracer.start(req.Context(), "HTTP "+req.Method, spanKindClient)
+	defer span.finish()
+
+	if span != nil {
+		span.setAttribute("http.method", req.Method)
+		span.setAttribute("http.url", req.URL.String())
+		req.Header.Set("traceparent", span.traceparent())
+	}
+
+	c.usage.record(req)
+
+	httpClient := c.HTTPClient
+	if timeout := c.requestOptions(req.Context()).RequestTimeout; timeout != httpClient.Timeout {
+		overridden := *httpClient
+		overridden.Timeout = timeout
+		httpClient = &overridden
+	}
+
+	r, err := httpClient.Do(req)
+	if err == nil {
+		if err = decompressBody(r); err != nil {
+			r.Body.Close()
+		}
+	}
+	if err == nil {
+		limitBody(r, c.maxResponseSize)
+	}
+	if err != nil {
+		span.setError(err.Error())
+		operation.setError(err.Error())
+		return nil, err
+	}
+
+	span.setAttribute("http.status_code", r.StatusCode)
+	if r.StatusCode >= 400 {
+		span.setError(r.Status)
+		operation.setError(r.Status)
+	} else {
+		operation.setError("")
+	}
+
+	return r, nil
+}
+
+// newJSONRequest returns a request sending body encoded as JSON.
+func newJSONRequest(ctx context.Context, method, url string, body interface{}) (*http.Request, error) {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(encoded))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return req, nil
+}
+
+// doJSON makes a single client operation of one request, sending body as
+// JSON unless it is nil, and decoding the response into v unless it is nil.
+// Responses with a status other than those expected fail with an *APIError.
+func (c *Client) doJSON(ctx context.Context, name, method, url string, body, v interface{}, expected ...int) error {
+	if err := c.initialize(ctx); err != nil {
+		return err
+	}
+
+	ctx, cancel := c.operationContext(ctx, name)
+	defer cancel()
+
+	var req *http.Request
+	var err error
+	if body != nil {
+		req, err = newJSONRequest(ctx, method, url, body)
+	} else {
+		req, err = http.NewRequestWithContext(ctx, method, url, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	r, err := c.doRequest(req)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+
+	if !slices.Contains(expected, r.StatusCode) {
+		return newAPIError(r)
+	}
+	if v == nil {
+		return nil
+	}
+	return decodeJSON(r, v)
+}
+
+func (c *Client) testAuth(ctx context.Context) (*http.Response, error) {
+	ctx, cancel := c.operationContext(ctx, "testAuth")
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.HostURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.doRequest(req)
+}
+
+// validateCredentials checks that the client's API token can access the
+// configured organization.
+func validateCredentials(ctx context.Context, client *Client) error {
+	r, err := client.testAuth(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to authenticate to Bugsnag: %w", err)
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != 200 {
+		return fmt.Errorf("unable to authenticate to Bugsnag API (%s) with the provided API token: %w", client.HostURL, newAPIError(r))
+	}
+
+	return nil
+}
+
+// initialize runs before the first API operation, so that creating a client
+// doesn't need to reach the API at all. It discovers the organization when
+// none was configured and validates the credentials. Only success is kept:
+// after a failure, e.g. a network error, the next operation tries again.
+//
+// The operations waiting for it share its result, so it isn't canceled with
+// the operation that happens to run it; each of its requests is still
+// bounded by the request timeout.
+func (c *Client) initialize(ctx context.Context) error {
+	c.initMu.Lock()
+	defer c.initMu.Unlock()
+
+	if c.initialized {
+		return nil
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	if c.OrganizationID == "" {
+		organizationID, err := c.discoverOrganizationID(ctx)
+		if err != nil {
+			return err
+		}
+		c.setOrganizationID(organizationID)
+	}
+
+	if c.ValidateCredentials {
+		if err := validateCredentials(ctx, c); err != nil {
+			return err
+		}
+	}
+
+	c.initialized = true
+	return nil
+}
+
+// discoverOrganizationID returns the ID of the only organization the API
+// token has access to.
+func (c *Client) discoverOrganizationID(ctx context.Context) (string, error) {
+	ctx, cancel := c.operationContext(ctx, "discoverOrganizationID")
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/user/organizations", c.BaseURL), nil)
+	if err != nil {
+		return "", err
+	}
+
+	r, err := c.doRequest(req)
+	if err != nil {
+		return "", err
+	}
+	defer r.Body.Close()
+
+	// https://bugsnagapiv2.docs.apiary.io/#reference/current-user/organizations/list-the-current-user's-organizations
+	if r.StatusCode != 200 {
+		return "", fmt.Errorf("no organization ID was configured, and listing the organizations the API token can access failed: %w", newAPIError(r))
+	}
+
+	organizations := make([]map[string]interface{}, 0)
+	if err := decodeJSON(r, &organizations); err != nil {
+		return "", err
+	}
+
+	if len(organizations) != 1 {
+		names := make([]string, 0, len(organizations))
+		for _, organization := range organizations {
+			names = append(names, fmt.Sprintf("%v (%v)", organization["name"], organization["id"]))
+		}
+
+		return "", fmt.Errorf("no organization ID was configured, and it can't be discovered because the API token has access to %d organizations: %s", len(organizations), strings.Join(names, ", "))
+	}
+
+	id, _ := organizations[0]["id"].(string)
+	return id, nil
+}
+
+// ProjectsIterator yields an organization's projects one at a time, fetching
+// each page of the list only once the previous one has been consumed. It
+// yields at most one error, after which iteration stops.
+type ProjectsIterator = iter.Seq2[*Project, error]
+
+// IterateProjects returns an iterator over every project of the
+// organization, so callers can filter or stop early without holding the
+// whole list in memory.
+func (c *Client) IterateProjects(ctx context.Context) ProjectsIterator {
+	return c.iterateProjects(ctx, "per_page=100")
+}
+
+// FindProjectsByName returns the projects of the organization named name.
+// Bugsnag filters the projects by name itself, so unlike a scan of
+// IterateProjects this takes a single request however many projects the
+// organization has.
+func (c *Client) FindProjectsByName(ctx context.Context, name string) ([]*Project, error) {
+	projects := make([]*Project, 0)
+	// the API matches names partially and regardless of case
+	for project, err := range c.iterateProjects(ctx, "per_page=100&q="+url.QueryEscape(name)) {
+		if err != nil {
+			return nil, err
+		}
+		if project.Name == name {
+			projects = append(projects, project)
+		}
+	}
+
+	return projects, nil
+}
+
+// iterateProjects iterates over the projects listed with the given query,
+// following the API's pagination.
+func (c *Client) iterateProjects(ctx context.Context, query string) ProjectsIterator {
+	return func(yield func(*Project, error) bool) {
+		if err := c.initialize(ctx); err != nil {
+			yield(nil, err)
+			return
+		}
+
+		for url := fmt.Sprintf("%s/projects?%s", c.HostURL, query); url != ""; {
+			var page []*Project
+			var err error
+
+			page, url, err = c.listProjectsPage(ctx, url)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, project := range page {
+				if !yield(project, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ListProjects returns every project of the organization, following the
+// API's pagination until the last page.
+func (c *Client) ListProjects(ctx context.Context) ([]*Project, error) {
+	projects := make([]*Project, 0)
+	for project, err := range c.IterateProjects(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+
+	return projects, nil
+}
+
+// projectsPage is a page of the project list, along with the URL of the next
+// page if there is one.
+type projectsPage struct {
+	projects []*Project
+	next     string
+}
+
+// listProjectsPage returns the projects on the page at url, and the URL of
+// the next page if there is one. Concurrent reads of the same page share a
+// single request.
+func (c *Client) listProjectsPage(ctx context.Context, url string) ([]*Project, string, error) {
+	page, err := c.flights.do(ctx, "GET "+url, func() (interface{}, error) {
+		return c.fetchProjectsPage(ctx, url)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return page.(*projectsPage).projects, page.(*projectsPage).next, nil
+}
+
+func (c *Client) fetchProjectsPage(ctx context.Context, url string) (*projectsPage, error) {
+	ctx, cancel := c.operationContext(ctx, "listProjects")
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	// https://bugsnagapiv2.docs.apiary.io/#reference/projects/projects/list-an-organization's-projects
+	if r.StatusCode != 200 {
+		return nil, newAPIError(r)
+	}
+
+	projects := make([]*Project, 0)
+	if err := decodeJSON(r, &projects); err != nil {
+		return nil, err
+	}
+
+	next, err := nextPageURL(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &projectsPage{projects: projects, next: next}, nil
+}
+
+// GetProject returns the project with the given ID. Concurrent reads of the
+// same project share a single request.
+func (c *Client) GetProject(ctx context.Context, projectID string) (*Project, error) {
+	if err := c.initialize(ctx); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/projects/%s", c.HostURL, projectID)
+	project, err := c.flights.do(ctx, "GET "+url, func() (interface{}, error) {
+		return c.fetchProject(ctx, url)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return project.(*Project), nil
+}
+
+func (c *Client) fetchProject(ctx context.Context, url string) (*Project, error) {
+	ctx, cancel := c.operationContext(ctx, "getProject")
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	// https://bugsnagapiv2.docs.apiary.io/#reference/projects/projects/view-a-project
+	if r.StatusCode != 200 {
+		return nil, newAPIError(r)
+	}
+
+	project := &Project{}
+	if err := decodeJSON(r, project); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// CreateProject creates a project and returns it.
+func (c *Client) CreateProject(ctx context.Context, request *CreateProjectRequest) (*Project, error) {
+	if err := c.initialize(ctx); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := c.operationContext(ctx, "createProject")
+	defer cancel()
+
+	req, err := newJSONRequest(ctx, "POST", fmt.Sprintf("%s/projects", c.HostURL), request)
+	if err != nil {
+		return nil, err
+	}
+	setIdempotencyKey(req)
+
+	r, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	// https://bugsnagapiv2.docs.apiary.io/#reference/projects/projects/create-a-project-in-an-organization
+	if r.StatusCode != 200 && r.StatusCode != 201 {
+		return nil, newAPIError(r)
+	}
+
+	project := &Project{}
+	if err := decodeJSON(r, project); err != nil {
+		return nil, err
+	}
+
+	if project.ID == "" {
+		return nil, fmt.Errorf("no project ID was retrieved for the created project %q", project.Name)
+	}
+
+	return project, nil
+}
+
+// UpdateProject changes the attributes of a project set in request, and
+// returns the updated project.
+func (c *Client) UpdateProject(ctx context.Context, projectID string, request *UpdateProjectRequest) (*Project, error) {
+	if err := c.initialize(ctx); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := c.operationContext(ctx, "updateProject")
+	defer cancel()
+
+	req, err := newJSONRequest(ctx, "PATCH", fmt.Sprintf("%s/projects/%s", c.HostURL, projectID), request)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	// https://bugsnagapiv2.docs.apiary.io/#reference/projects/projects/update-a-project
+	if r.StatusCode != 200 {
+		return nil, newAPIError(r)
+	}
+
+	project := &Project{}
+	if err := decodeJSON(r, project); err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
+// DeleteProject deletes the project with the given ID.
+func (c *Client) DeleteProject(ctx context.Context, projectID string) error {
+	if err := c.initialize(ctx); err != nil {
+		return err
+	}
+
+	ctx, cancel := c.operationContext(ctx, "deleteProject")
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/projects/%s", c.HostURL, projectID), nil)
+	if err != nil {
+		return err
+	}
+
+	r, err := c.doRequest(req)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+
+	// https://bugsnagapiv2.docs.apiary.io/#reference/projects/projects/delete-a-project
+	if r.StatusCode != 200 && r.StatusCode != 204 {
+		return newAPIError(r)
+	}
+
+	return nil
+}
+
+// RegenerateProjectAPIKey replaces the notifier API key of a project, and
+// returns the new key. Notifiers using the old key stop being accepted.
+func (c *Client) RegenerateProjectAPIKey(ctx context.Context, projectID string) (string, error) {
+	if err := c.initialize(ctx); err != nil {
+		return "", err
+	}
+
+	ctx, cancel := c.operationContext(ctx, "regenerateProjectAPIKey")
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/projects/%s/api_key", c.HostURL, projectID), nil)
+	if err != nil {
+		return "", err
+	}
+	// a retried request must not rotate the key again
+	setIdempotencyKey(req)
+
+	r, err := c.doRequest(req)
+	if err != nil {
+		return "", err
+	}
+	defer r.Body.Close()
+
+	// https://bugsnagapiv2.docs.apiary.io/#reference/projects/projects/regenerate-a-project's-notifier-api-key
+	if r.StatusCode != 200 {
+		return "", newAPIError(r)
+	}
+
+	key := &projectAPIKey{}
+	if err := decodeJSON(r, key); err != nil {
+		return "", err
+	}
+
+	return key.APIKey, nil
+}
+
+// CreateAccessToken exchanges the client's credentials for a data access
+// token that expires after ttl.
+func (c *Client) CreateAccessToken(ctx context.Context, ttl time.Duration) (*AccessToken, error) {
+	if err := c.initialize(ctx); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := c.operationContext(ctx, "createAccessToken")
+	defer cancel()
+
+	req, err := newJSONRequest(ctx, "POST", fmt.Sprintf("%s/access_tokens", c.HostURL), map[string]interface{}{
+		"expires_in": int64(ttl / time.Second),
+	})
+	if err != nil {
+		return nil, err
+	}
+	setIdempotencyKey(req)
+
+	r, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != 200 && r.StatusCode != 201 {
+		return nil, newAPIError(r)
+	}
+
+	token := &AccessToken{}
+	if err := decodeJSON(r, token); err != nil {
+		return nil, err
+	}
+
+	if token.Token == "" {
+		return nil, fmt.Errorf("the Bugsnag API accepted the request but returned no access token")
+	}
+
+	return token, nil
+}
+
+// RevokeAccessToken revokes a token issued by CreateAccessToken before it
+// expires.
+func (c *Client) RevokeAccessToken(ctx context.Context, id string) error {
+	if err := c.initialize(ctx); err != nil {
+		return err
+	}
+
+	ctx, cancel := c.operationContext(ctx, "revokeAccessToken")
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/access_tokens/%s", c.HostURL, id), nil)
+	if err != nil {
+		return err
+	}
+
+	r, err := c.doRequest(req)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != 200 && r.StatusCode != 204 {
+		return newAPIError(r)
+	}
+
+	return nil
+}
+
+// RateLimitWarning reports the remaining rate limit quota and when it
+// resets (zero if unknown) the first time the quota drops to or below
+// ClientConfig.RateLimitWarningThreshold. It reports nothing more until the
+// quota recovers again.
+func (c *Client) RateLimitWarning() (remaining int, reset time.Time, ok bool) {
+	return c.rateLimit.warning()
+}
+
+// UsageSummary describes the requests sent so far, per endpoint. It reports
+// nothing unless ClientConfig.UsageSummary is set and a request was sent.
+func (c *Client) UsageSummary() (summary string, ok bool) {
+	if c.usage == nil {
+		return "", false
+	}
+
+	c.usage.mu.Lock()
+	sent := len(c.usage.calls) > 0
+	c.usage.mu.Unlock()
+	if !sent {
+		return "", false
+	}
+	return c.usage.summary(), true
+}
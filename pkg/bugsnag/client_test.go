@@ -0,0 +1,626 @@
+package bugsnag
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-bugsnag/internal/bugsnagtest"
+)
+
+func TestClientDiscoversOrganizationID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/user/organizations":
+			fmt.Fprint(w, `[{"id": "515fb9337c1074f6fd000001", "name": "Acme"}]`)
+		case "/organizations/515fb9337c1074f6fd000001/projects":
+			fmt.Fprint(w, `[{"id": "1", "name": "api"}]`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(404)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(ClientConfig{BaseURL: server.URL})
+
+	projects, err := c.ListProjects(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if c.OrganizationID != "515fb9337c1074f6fd000001" {
+		t.Fatalf("expected the organization ID to be discovered, got %q", c.OrganizationID)
+	}
+	if len(projects) != 1 {
+		t.Fatalf("expected 1 project, got %d", len(projects))
+	}
+}
+
+func TestClientOrganizationDiscoveryRequiresSingleOrganization(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": "1", "name": "Acme"}, {"id": "2", "name": "Initech"}]`)
+	}))
+	defer server.Close()
+
+	c := NewClient(ClientConfig{BaseURL: server.URL})
+
+	if _, err := c.ListProjects(context.Background()); err == nil {
+		t.Fatalf("expected an error when the token can access several organizations")
+	}
+}
+
+func TestClientRetriesOrganizationDiscovery(t *testing.T) {
+	discoveries := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/user/organizations":
+			discoveries++
+			if discoveries == 1 {
+				w.WriteHeader(401)
+				return
+			}
+			fmt.Fprint(w, `[{"id": "1", "name": "Acme"}]`)
+		case "/organizations/1/projects":
+			fmt.Fprint(w, `[]`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(404)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(ClientConfig{BaseURL: server.URL})
+
+	if _, err := c.ListProjects(context.Background()); err == nil {
+		t.Fatalf("expected the failed discovery to fail the operation")
+	}
+	if _, err := c.ListProjects(context.Background()); err != nil {
+		t.Fatalf("expected the next operation to discover the organization again, got %s", err)
+	}
+	if _, err := c.ListProjects(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if discoveries != 2 {
+		t.Fatalf("expected the discovered organization to be kept, got %d discoveries", discoveries)
+	}
+}
+
+func TestClientInitializationOutlivesCanceledOperation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": "1", "name": "Acme"}]`)
+	}))
+	defer server.Close()
+
+	c := NewClient(ClientConfig{BaseURL: server.URL})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.initialize(ctx); err != nil {
+		t.Fatalf("expected the organization to be discovered for the operations waiting on it, got %s", err)
+	}
+	if c.OrganizationID != "1" {
+		t.Fatalf("expected the organization ID to be discovered, got %q", c.OrganizationID)
+	}
+}
+
+func TestClientAccessTokenLifecycle(t *testing.T) {
+	revoked := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/organizations/1/access_tokens":
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("unexpected request body: %s", err)
+			}
+			if body["expires_in"] != float64(900) {
+				t.Errorf("expected expires_in 900, got %v", body["expires_in"])
+			}
+			w.WriteHeader(201)
+			fmt.Fprint(w, `{"id": "t1", "token": "secret", "expires_at": "2024-01-01T00:15:00Z"}`)
+		case r.Method == "DELETE" && r.URL.Path == "/organizations/1/access_tokens/t1":
+			revoked = true
+			w.WriteHeader(204)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(404)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(ClientConfig{BaseURL: server.URL, OrganizationID: "1"})
+
+	token, err := c.CreateAccessToken(context.Background(), 15*time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if token.ID != "t1" || token.Token != "secret" {
+		t.Fatalf("unexpected token: %+v", token)
+	}
+
+	if err := c.RevokeAccessToken(context.Background(), token.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !revoked {
+		t.Fatalf("expected the token to be revoked")
+	}
+}
+
+func TestClientHonoursCancelledContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	c := NewClient(ClientConfig{BaseURL: server.URL, OrganizationID: "1"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := c.GetProject(ctx, "1"); err == nil {
+		t.Fatalf("expected an error when the context is cancelled")
+	}
+}
+
+func TestClientReportsUnreadableErrorBodies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the body ends before the announced length, so reading it fails
+		w.Header().Set("Content-Length", "100")
+		w.WriteHeader(400)
+		fmt.Fprint(w, "truncated")
+	}))
+	defer server.Close()
+
+	c := NewClient(ClientConfig{BaseURL: server.URL, OrganizationID: "1"})
+
+	if _, err := c.GetProject(context.Background(), "1"); err == nil {
+		t.Fatalf("expected an error when the response body can't be read")
+	}
+	if _, err := c.CreateProject(context.Background(), &CreateProjectRequest{Name: "api", Type: "go"}); err == nil {
+		t.Fatalf("expected an error when the response body can't be read")
+	}
+}
+
+func TestClientReturnsTypedErrors(t *testing.T) {
+	cases := map[int]error{
+		401: ErrUnauthorized,
+		403: ErrUnauthorized,
+		404: ErrNotFound,
+		409: ErrConflict,
+		429: ErrRateLimited,
+	}
+
+	for status, want := range cases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			fmt.Fprint(w, `{"errors": ["something went wrong"]}`)
+		}))
+
+		c := NewClient(ClientConfig{BaseURL: server.URL, OrganizationID: "1"})
+
+		_, err := c.GetProject(context.Background(), "1")
+		if !errors.Is(err, want) {
+			t.Errorf("status %d: expected %v, got %v", status, want, err)
+		}
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.Message != "something went wrong" {
+			t.Errorf("status %d: expected an *APIError with the response's message, got %v", status, err)
+		}
+
+		server.Close()
+	}
+}
+
+func TestClientSendsProjectRequestsAsJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("expected a JSON body, got content type %q", r.Header.Get("Content-Type"))
+		}
+		if r.URL.RawQuery != "" {
+			t.Errorf("expected no query parameters, got %q", r.URL.RawQuery)
+		}
+
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("unexpected request body: %s", err)
+		}
+		if body["name"] != "api & web" || body["ignore_old_browsers"] != true {
+			t.Errorf("unexpected request body: %v", body)
+		}
+
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/organizations/1/projects":
+			fmt.Fprint(w, `{"id": "2", "name": "api & web"}`)
+		case r.Method == "PATCH" && r.URL.Path == "/organizations/1/projects/2":
+			fmt.Fprint(w, `{"id": "2", "name": "api & web"}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(404)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(ClientConfig{BaseURL: server.URL, OrganizationID: "1"})
+
+	name, ignoreOldBrowsers := "api & web", true
+
+	project, err := c.CreateProject(context.Background(), &CreateProjectRequest{
+		Name:              name,
+		Type:              "go",
+		IgnoreOldBrowsers: &ignoreOldBrowsers,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if project.ID != "2" {
+		t.Fatalf("expected project 2, got %q", project.ID)
+	}
+
+	_, err = c.UpdateProject(context.Background(), project.ID, &UpdateProjectRequest{
+		Name:              &name,
+		IgnoreOldBrowsers: &ignoreOldBrowsers,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestClientUsesConfiguredTransport(t *testing.T) {
+	requests := 0
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		requests++
+		if r.URL.String() != "https://bugsnag.example.com/organizations/1/projects/2" {
+			t.Errorf("unexpected request to %s", r.URL)
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"id": "2", "name": "api"}`)),
+			Request:    r,
+		}, nil
+	})
+
+	c := NewClient(ClientConfig{BaseURL: "https://bugsnag.example.com", OrganizationID: "1", Transport: transport})
+
+	project, err := c.GetProject(context.Background(), "2")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if project.Name != "api" {
+		t.Fatalf("unexpected project: %+v", project)
+	}
+	if requests != 1 {
+		t.Fatalf("expected 1 request through the transport, got %d", requests)
+	}
+}
+
+func TestClientAgainstFixtureServer(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+
+	c := NewClient(ClientConfig{BaseURL: server.URL, APIToken: "token"})
+	ctx := context.Background()
+
+	for i := 0; i < 120; i++ {
+		server.AddProject(map[string]interface{}{"name": fmt.Sprintf("project %d", i), "type": "go"})
+	}
+
+	projects, err := c.ListProjects(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(projects) != 120 {
+		t.Fatalf("expected 120 projects across both pages, got %d", len(projects))
+	}
+
+	ignoreOldBrowsers := true
+	project, err := c.CreateProject(ctx, &CreateProjectRequest{Name: "api", Type: "go", IgnoreOldBrowsers: &ignoreOldBrowsers})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if project.OrganizationID != bugsnagtest.OrganizationID || project.APIKey == "" || !project.IgnoreOldBrowsers {
+		t.Fatalf("unexpected project: %+v", project)
+	}
+
+	name := "api-v2"
+	if _, err := c.UpdateProject(ctx, project.ID, &UpdateProjectRequest{Name: &name}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	project, err = c.GetProject(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if project.Name != "api-v2" {
+		t.Fatalf("expected the project to be renamed, got %q", project.Name)
+	}
+
+	key, err := c.RegenerateProjectAPIKey(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if key == "" || key == project.APIKey {
+		t.Fatalf("expected a new API key, got %q", key)
+	}
+
+	notifications, err := c.UpdateEmailNotificationSettings(ctx, project.ID, &EmailNotificationSettings{NewErrors: true, DailySummary: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if notifications.ReopenedErrors || !notifications.DailySummary {
+		t.Fatalf("expected the settings to be replaced, got %+v", notifications)
+	}
+	if notifications, err = c.GetEmailNotificationSettings(ctx, project.ID); err != nil || !notifications.DailySummary {
+		t.Fatalf("expected the updated settings to be read, got %+v, %v", notifications, err)
+	}
+
+	if _, err := c.UpdateReopenRules(ctx, project.ID, &ReopenRules{Enabled: true, Occurrences: 3, Hours: 6}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if rules, err := c.GetReopenRules(ctx, project.ID); err != nil || !rules.Enabled || rules.Occurrences != 3 || rules.Hours != 6 {
+		t.Fatalf("expected the updated rules to be read, got %+v, %v", rules, err)
+	}
+
+	spikes := &SpikeDetectionSettings{Enabled: true, ThresholdMultiplier: 2.5, MinimumEvents: 50, WindowMinutes: 15}
+	if _, err := c.UpdateSpikeDetectionSettings(ctx, project.ID, spikes); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if settings, err := c.GetSpikeDetectionSettings(ctx, project.ID); err != nil || *settings != *spikes {
+		t.Fatalf("expected the updated settings to be read, got %+v, %v", settings, err)
+	}
+
+	target := 99.9
+	if _, err := c.UpdateStabilityTargets(ctx, project.ID, &StabilityTargets{TargetStability: &target}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if targets, err := c.GetStabilityTargets(ctx, project.ID); err != nil || *targets.TargetStability != target || targets.CriticalStability == nil {
+		t.Fatalf("expected the target to be updated alone, got %+v, %v", targets, err)
+	}
+
+	if stability, err := c.GetProjectStability(ctx, project.ID); err != nil || stability.UserStability != nil || stability.SessionStability != nil {
+		t.Fatalf("expected no stability for a project without sessions, got %+v, %v", stability, err)
+	}
+	server.SetStability(project.ID, 97.5, 99.25)
+	if stability, err := c.GetProjectStability(ctx, project.ID); err != nil || *stability.UserStability != 97.5 || *stability.SessionStability != 99.25 {
+		t.Fatalf("expected the stability to be read, got %+v, %v", stability, err)
+	}
+
+	if usage, err := c.GetEventUsage(ctx, project.ID); err != nil || usage.EventsUsed != 0 || usage.EventQuota != nil {
+		t.Fatalf("expected no event usage for a new project, got %+v, %v", usage, err)
+	}
+	server.SetEventUsage(project.ID, 2500, 10000)
+	if usage, err := c.GetEventUsage(ctx, project.ID); err != nil || usage.EventsUsed != 2500 || *usage.EventQuota != 10000 {
+		t.Fatalf("expected the event usage to be read, got %+v, %v", usage, err)
+	}
+
+	field := &EventField{DisplayID: "tenant_id", Path: "metaData.tenant.id", FilterOptions: EventFieldFilterOptions{Name: "Tenant"}}
+	if _, err := c.CreateEventField(ctx, project.ID, field); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	field.PivotOptions = &EventFieldPivotOptions{Name: "Tenant"}
+	if _, err := c.UpdateEventField(ctx, project.ID, "tenant_id", field); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	fields, err := c.ListEventFields(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(fields) != 2 || !fields[1].Custom || fields[1].PivotOptions == nil {
+		t.Fatalf("expected the built-in and the updated custom field, got %+v", fields)
+	}
+	if err := c.DeleteEventField(ctx, project.ID, "tenant_id"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := c.InviteCollaborators(ctx, []string{"dev@example.com"}, []string{project.ID}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	member := server.AddCollaborator("ops@example.com")
+	if err := c.AddCollaboratorProjects(ctx, member["id"].(string), []string{project.ID}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := c.AddCollaboratorProjects(ctx, "missing", []string{project.ID}); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for an unknown collaborator, got %v", err)
+	}
+
+	collaborator, err := c.InviteCollaborator(ctx, &InviteCollaboratorRequest{Email: "qa@example.com", ProjectIDs: []string{project.ID}})
+	if err != nil || !collaborator.PendingInvitation || len(collaborator.ProjectIDs) != 1 {
+		t.Fatalf("expected the collaborator to be invited, got %+v, %v", collaborator, err)
+	}
+	admin, none := true, []string{}
+	if _, err := c.UpdateCollaborator(ctx, collaborator.ID, &UpdateCollaboratorRequest{Admin: &admin, ProjectIDs: &none}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if collaborator, err := c.GetCollaborator(ctx, collaborator.ID); err != nil || !collaborator.IsAdmin || len(collaborator.ProjectIDs) != 0 {
+		t.Fatalf("expected the permissions to be updated, got %+v, %v", collaborator, err)
+	}
+	if err := c.ResendCollaboratorInvitation(ctx, collaborator.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if collaborators, err := c.ListCollaborators(ctx); err != nil || len(collaborators) != 3 {
+		t.Fatalf("expected every collaborator to be listed, got %v, %v", collaborators, err)
+	}
+	if err := c.DeleteCollaborator(ctx, collaborator.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := c.GetCollaborator(ctx, collaborator.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a removed collaborator, got %v", err)
+	}
+
+	if _, err := c.GetTeamProject(ctx, "missing", project.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a missing team, got %v", err)
+	}
+	team := server.AddTeam()
+	if access, err := c.SetTeamProject(ctx, team, project.ID, TeamPermissionAdmin); err != nil || access.Permission != TeamPermissionAdmin {
+		t.Fatalf("expected the team to be given access, got %+v, %v", access, err)
+	}
+	if access, err := c.GetTeamProject(ctx, team, project.ID); err != nil || access.Permission != TeamPermissionAdmin {
+		t.Fatalf("expected the access of the team, got %+v, %v", access, err)
+	}
+	if err := c.RemoveTeamProject(ctx, team, project.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := c.AddAllowedEmailDomain(ctx, "example.com"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if domains, err := c.ListAllowedEmailDomains(ctx); err != nil || len(domains) != 1 || domains[0] != "example.com" {
+		t.Fatalf("expected the allowed domain to be listed, got %v, %v", domains, err)
+	}
+	if err := c.RemoveAllowedEmailDomain(ctx, "example.com"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := c.RemoveAllowedEmailDomain(ctx, "example.com"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a removed domain, got %v", err)
+	}
+
+	if _, err := c.GetSSOConfiguration(ctx); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound without SSO, got %v", err)
+	}
+	sso, err := c.UpdateSSOConfiguration(ctx, &SSOConfiguration{IdPMetadataURL: "https://idp.example.com/metadata", Enforced: true})
+	if err != nil || !sso.Enforced || sso.DefaultRole != SSORoleCollaborator || sso.ACSURL == "" {
+		t.Fatalf("expected SSO to be configured, got %+v, %v", sso, err)
+	}
+	if err := c.DeleteSSOConfiguration(ctx); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	webhook, err := c.CreateWebhook(ctx, project.ID, &CreateWebhookRequest{URL: "https://example.com/hook", Secret: "s3cret", Triggers: []string{WebhookTriggerNewError}})
+	if err != nil || webhook.URL != "https://example.com/hook" || len(webhook.Triggers) != 1 {
+		t.Fatalf("expected the webhook to be created, got %+v, %v", webhook, err)
+	}
+	triggers := []string{WebhookTriggerEveryEvent}
+	if webhook, err := c.UpdateWebhook(ctx, project.ID, webhook.ID, &UpdateWebhookRequest{Triggers: &triggers}); err != nil || webhook.Triggers[0] != WebhookTriggerEveryEvent {
+		t.Fatalf("expected the triggers to be updated, got %+v, %v", webhook, err)
+	}
+	if err := c.DeleteWebhook(ctx, project.ID, webhook.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := c.GetWebhook(ctx, project.ID, webhook.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a deleted webhook, got %v", err)
+	}
+
+	if _, err := c.GetGitLabIntegration(ctx, project.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound without a GitLab integration, got %v", err)
+	}
+	gitlab, err := c.UpdateGitLabIntegration(ctx, project.ID, &GitLabIntegration{InstanceURL: "https://gitlab.com", ProjectPath: "group/project", Token: "glpat"})
+	if err != nil || gitlab.ProjectPath != "group/project" || gitlab.Token != "" {
+		t.Fatalf("expected the integration to be created without returning the token, got %+v, %v", gitlab, err)
+	}
+	if err := c.DeleteGitLabIntegration(ctx, project.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	token, err := c.CreateAccessToken(ctx, time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := c.RevokeAccessToken(ctx, token.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := c.RevokeAccessToken(ctx, token.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected revoking a revoked token to fail with ErrNotFound, got %v", err)
+	}
+}
+
+func TestFindProjectsByName(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+	for i := 0; i < 250; i++ {
+		server.AddProject(map[string]interface{}{"name": fmt.Sprintf("service-%d", i), "type": "go"})
+	}
+	server.AddProject(map[string]interface{}{"name": "api", "type": "go"})
+	server.AddProject(map[string]interface{}{"name": "API-v2", "type": "go"})
+
+	c := NewClient(ClientConfig{BaseURL: server.URL, APIToken: "token", OrganizationID: bugsnagtest.OrganizationID})
+
+	projects, err := c.FindProjectsByName(context.Background(), "api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(projects) != 1 || projects[0].Name != "api" {
+		t.Fatalf("expected only the project named api, got %v", projects)
+	}
+
+	listed := 0
+	for _, request := range server.Requests() {
+		if strings.HasSuffix(request, "/projects") {
+			listed++
+		}
+	}
+	if listed != 1 {
+		t.Fatalf("expected a single request, got %d", listed)
+	}
+}
+
+func TestClientFixtureServerFailures(t *testing.T) {
+	projectsPath := "/organizations/" + bugsnagtest.OrganizationID + "/projects"
+	ctx := context.Background()
+
+	t.Run("not found", func(t *testing.T) {
+		server := bugsnagtest.NewServer()
+		defer server.Close()
+		c := NewClient(ClientConfig{BaseURL: server.URL, APIToken: "token", OrganizationID: bugsnagtest.OrganizationID})
+
+		if _, err := c.GetProject(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+		name := "api"
+		if _, err := c.UpdateProject(ctx, "missing", &UpdateProjectRequest{Name: &name}); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("rate limited", func(t *testing.T) {
+		server := bugsnagtest.NewServer()
+		defer server.Close()
+		c := NewClient(ClientConfig{BaseURL: server.URL, APIToken: "token", OrganizationID: bugsnagtest.OrganizationID, MaxRateLimitRetries: 1})
+
+		server.Fail("GET", projectsPath, 429)
+		if _, err := c.ListProjects(ctx); err != nil {
+			t.Fatalf("expected the rate limited request to be retried, got %v", err)
+		}
+
+		server.Fail("GET", projectsPath, 429, 429)
+		if _, err := c.ListProjects(ctx); !errors.Is(err, ErrRateLimited) {
+			t.Fatalf("expected ErrRateLimited once the retries are used up, got %v", err)
+		}
+	})
+
+	t.Run("server errors", func(t *testing.T) {
+		server := bugsnagtest.NewServer()
+		defer server.Close()
+		c := NewClient(ClientConfig{BaseURL: server.URL, APIToken: "token", OrganizationID: bugsnagtest.OrganizationID, MaxRetries: 1})
+
+		server.Fail("POST", projectsPath, 503)
+		if _, err := c.CreateProject(ctx, &CreateProjectRequest{Name: "api", Type: "go"}); err != nil {
+			t.Fatalf("expected the create to be retried, got %v", err)
+		}
+		projects, err := c.ListProjects(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(projects) != 1 {
+			t.Fatalf("expected exactly 1 project, got %d", len(projects))
+		}
+
+		server.Fail("GET", projectsPath+"/"+projects[0].ID, 500)
+		var apiErr *APIError
+		if _, err := c.GetProject(ctx, projects[0].ID); !errors.As(err, &apiErr) || apiErr.StatusCode != 500 {
+			t.Fatalf("expected a 500 *APIError, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package bugsnag
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// InviteCollaborators gives the people with the given emails access to the
+// given projects, inviting to the organization those who aren't members.
+func (c *Client) InviteCollaborators(ctx context.Context, emails []string, projectIDs []string) error {
+	endpoint := fmt.Sprintf("%s/collaborators", c.HostURL)
+	return c.doJSON(ctx, "inviteCollaborators", "POST", endpoint, map[string]interface{}{
+		"emails":      emails,
+		"project_ids": projectIDs,
+	}, nil, 200, 201)
+}
+
+// AddCollaboratorProjects gives the collaborator with the given ID access to
+// the given projects, in addition to those it can already access.
+func (c *Client) AddCollaboratorProjects(ctx context.Context, collaboratorID string, projectIDs []string) error {
+	endpoint := fmt.Sprintf("%s/collaborators/%s/projects", c.HostURL, url.PathEscape(collaboratorID))
+	return c.doJSON(ctx, "addCollaboratorProjects", "PATCH", endpoint, map[string]interface{}{
+		"add_project_ids": projectIDs,
+	}, nil, 200)
+}
+
+// InviteCollaborator invites someone to the organization with the given
+// permissions, and returns the collaborator. Inviting a member of the
+// organization returns the existing collaborator, with access to the given
+// projects in addition to those it could already access.
+func (c *Client) InviteCollaborator(ctx context.Context, request *InviteCollaboratorRequest) (*Collaborator, error) {
+	collaborator := &Collaborator{}
+	endpoint := fmt.Sprintf("%s/collaborators", c.HostURL)
+	if err := c.doJSON(ctx, "inviteCollaborator", "POST", endpoint, request, collaborator, 200, 201); err != nil {
+		return nil, err
+	}
+
+	return collaborator, nil
+}
+
+// ListCollaborators returns the members of the organization, and those
+// invited to it, following the API's pagination until the last page.
+func (c *Client) ListCollaborators(ctx context.Context) ([]*Collaborator, error) {
+	if err := c.initialize(ctx); err != nil {
+		return nil, err
+	}
+
+	collaborators := make([]*Collaborator, 0)
+	for url := fmt.Sprintf("%s/collaborators?per_page=100", c.HostURL); url != ""; {
+		var page []*Collaborator
+		var err error
+
+		page, url, err = c.fetchCollaboratorsPage(ctx, url)
+		if err != nil {
+			return nil, err
+		}
+		collaborators = append(collaborators, page...)
+	}
+
+	return collaborators, nil
+}
+
+// fetchCollaboratorsPage returns the collaborators on the page at url, and
+// the URL of the next page if there is one.
+func (c *Client) fetchCollaboratorsPage(ctx context.Context, url string) ([]*Collaborator, string, error) {
+	ctx, cancel := c.operationContext(ctx, "listCollaborators")
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	r, err := c.doRequest(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != 200 {
+		return nil, "", newAPIError(r)
+	}
+
+	collaborators := make([]*Collaborator, 0)
+	if err := decodeJSON(r, &collaborators); err != nil {
+		return nil, "", err
+	}
+
+	next, err := nextPageURL(r)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return collaborators, next, nil
+}
+
+// GetCollaborator returns the collaborator with the given ID.
+func (c *Client) GetCollaborator(ctx context.Context, collaboratorID string) (*Collaborator, error) {
+	collaborator := &Collaborator{}
+	endpoint := fmt.Sprintf("%s/collaborators/%s", c.HostURL, url.PathEscape(collaboratorID))
+	if err := c.doJSON(ctx, "getCollaborator", "GET", endpoint, nil, collaborator, 200); err != nil {
+		return nil, err
+	}
+
+	return collaborator, nil
+}
+
+// UpdateCollaborator changes the permissions of a collaborator set in
+// request, and returns the updated collaborator.
+func (c *Client) UpdateCollaborator(ctx context.Context, collaboratorID string, request *UpdateCollaboratorRequest) (*Collaborator, error) {
+	collaborator := &Collaborator{}
+	endpoint := fmt.Sprintf("%s/collaborators/%s", c.HostURL, url.PathEscape(collaboratorID))
+	if err := c.doJSON(ctx, "updateCollaborator", "PATCH", endpoint, request, collaborator, 200); err != nil {
+		return nil, err
+	}
+
+	return collaborator, nil
+}
+
+// DeleteCollaborator removes a collaborator from the organization, or
+// revokes their invitation.
+func (c *Client) DeleteCollaborator(ctx context.Context, collaboratorID string) error {
+	endpoint := fmt.Sprintf("%s/collaborators/%s", c.HostURL, url.PathEscape(collaboratorID))
+	return c.doJSON(ctx, "deleteCollaborator", "DELETE", endpoint, nil, nil, 200, 204)
+}
+
+// ResendCollaboratorInvitation sends the invitation to the organization of a
+// collaborator who hasn't accepted it again.
+func (c *Client) ResendCollaboratorInvitation(ctx context.Context, collaboratorID string) error {
+	endpoint := fmt.Sprintf("%s/collaborators/%s/resend_invitation", c.HostURL, url.PathEscape(collaboratorID))
+	return c.doJSON(ctx, "resendCollaboratorInvitation", "POST", endpoint, nil, nil, 200, 202, 204)
+}
@@ -0,0 +1,45 @@
+package bugsnag
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// gzipBody is a response body decompressed on the fly. Closing it closes the
+// underlying compressed body too.
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b *gzipBody) Close() error {
+	b.Reader.Close()
+	return b.body.Close()
+}
+
+// decompressBody replaces the body of a gzip-encoded response with its
+// decompressed content. Other responses are left untouched.
+func decompressBody(r *http.Response) error {
+	if !strings.EqualFold(r.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+
+	reader, err := gzip.NewReader(r.Body)
+	if err != nil {
+		// an empty body, e.g. of a 204 response, has no gzip header
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	}
+
+	r.Body = &gzipBody{Reader: reader, body: r.Body}
+	r.Header.Del("Content-Encoding")
+	r.Header.Del("Content-Length")
+	r.ContentLength = -1
+	r.Uncompressed = true
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package bugsnag
+
+import (
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientDecompressesResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			t.Errorf("expected gzip to be accepted, got %q", r.Header.Get("Accept-Encoding"))
+		}
+
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(`[{"id": "1", "name": "api"}]`))
+		gz.Close()
+	}))
+	defer server.Close()
+
+	c := NewClient(ClientConfig{BaseURL: server.URL, OrganizationID: "1"})
+
+	projects, err := c.ListProjects(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(projects) != 1 || projects[0].Name != "api" {
+		t.Fatalf("unexpected projects: %v", projects)
+	}
+}
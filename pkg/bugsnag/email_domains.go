@@ -0,0 +1,40 @@
+package bugsnag
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// ListAllowedEmailDomains returns the email domains people can be invited
+// to the organization with, or none if invitations aren't restricted.
+func (c *Client) ListAllowedEmailDomains(ctx context.Context) ([]string, error) {
+	domains := make([]*AllowedEmailDomain, 0)
+	// organizations allow a few domains at most, so a single page holds them
+	endpoint := fmt.Sprintf("%s/allowed_email_domains?per_page=100", c.HostURL)
+	if err := c.doJSON(ctx, "listAllowedEmailDomains", "GET", endpoint, nil, &domains, 200); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		names = append(names, domain.Domain)
+	}
+	return names, nil
+}
+
+// AddAllowedEmailDomain lets people be invited to the organization with an
+// email of the given domain. Adding the first domain restricts invitations
+// to the allowed domains.
+func (c *Client) AddAllowedEmailDomain(ctx context.Context, domain string) error {
+	endpoint := fmt.Sprintf("%s/allowed_email_domains", c.HostURL)
+	return c.doJSON(ctx, "addAllowedEmailDomain", "POST", endpoint, &AllowedEmailDomain{Domain: domain}, nil, 200, 201)
+}
+
+// RemoveAllowedEmailDomain stops people being invited to the organization
+// with an email of the given domain. Removing the last domain lifts the
+// restriction on invitations altogether.
+func (c *Client) RemoveAllowedEmailDomain(ctx context.Context, domain string) error {
+	endpoint := fmt.Sprintf("%s/allowed_email_domains/%s", c.HostURL, url.PathEscape(domain))
+	return c.doJSON(ctx, "removeAllowedEmailDomain", "DELETE", endpoint, nil, nil, 200, 204)
+}
@@ -0,0 +1,129 @@
+package bugsnag
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// Errors wrapped by the errors the client returns for failed API requests,
+// so callers can tell failures apart with errors.Is.
+var (
+	// ErrNotFound means the object doesn't exist, e.g. because it was
+	// deleted outside of Terraform.
+	ErrNotFound = errors.New("not found")
+
+	// ErrUnauthorized means the API token is invalid or can't access the
+	// object.
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrRateLimited means the request was still rate limited once the
+	// allowed retries were used up.
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrConflict means the request conflicts with the object's current
+	// state, e.g. a project with the same name already exists.
+	ErrConflict = errors.New("conflict")
+
+	// ErrUnexpectedResponse means the response doesn't match the model the
+	// client expects, e.g. because the API renamed or retyped a field.
+	ErrUnexpectedResponse = errors.New("unexpected response")
+)
+
+// requestIDHeaders are the response headers the request ID may be found in,
+// which Bugsnag support needs to look into a failed request.
+var requestIDHeaders = []string{"X-Request-Id", "Request-Id"}
+
+// APIError is returned for requests the Bugsnag API responded to with an
+// error status.
+type APIError struct {
+	StatusCode int
+	Method     string
+	Path       string
+	RequestID  string
+	Message    string
+
+	// err is the sentinel error matching StatusCode, if any.
+	err error
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+
+	if e.Method != "" {
+		fmt.Fprintf(&b, "%s %s: ", e.Method, e.Path)
+	}
+	fmt.Fprintf(&b, "Bugsnag API responded with status %d", e.StatusCode)
+	if text := http.StatusText(e.StatusCode); text != "" {
+		fmt.Fprintf(&b, " %s", text)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request ID %s)", e.RequestID)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+
+	return b.String()
+}
+
+func (e *APIError) Unwrap() error {
+	return e.err
+}
+
+// newAPIError returns the error for the error response r, consuming its
+// body.
+func newAPIError(r *http.Response) error {
+	apiErr := &APIError{
+		StatusCode: r.StatusCode,
+	}
+
+	if r.Request != nil {
+		apiErr.Method = r.Request.Method
+		apiErr.Path = r.Request.URL.Path
+	}
+
+	for _, header := range requestIDHeaders {
+		if id := r.Header.Get(header); id != "" {
+			apiErr.RequestID = id
+			break
+		}
+	}
+
+	switch r.StatusCode {
+	case 401, 403:
+		apiErr.err = ErrUnauthorized
+	case 404:
+		apiErr.err = ErrNotFound
+	case 409:
+		apiErr.err = ErrConflict
+	case 429:
+		apiErr.err = ErrRateLimited
+	}
+
+	// the status alone tells a missing object apart, even when the body is
+	// cut short, so the error wraps both
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("%w: reading the response body: %w", apiErr, err)
+	}
+	apiErr.Message = errorMessage(body)
+
+	return apiErr
+}
+
+// errorMessage extracts the messages of an error response body, which the
+// API sends as {"errors": ["..."]}, falling back to the raw body.
+func errorMessage(body []byte) string {
+	var payload struct {
+		Errors []string `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil && len(payload.Errors) > 0 {
+		return strings.Join(payload.Errors, "; ")
+	}
+
+	return strings.TrimSpace(string(body))
+}
@@ -0,0 +1,67 @@
+package bugsnag
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestAPIErrorMessage(t *testing.T) {
+	r := &http.Response{
+		StatusCode: 422,
+		Header:     http.Header{"X-Request-Id": []string{"abc123"}},
+		Body:       ioutil.NopCloser(strings.NewReader(`{"errors": ["name has already been taken"]}`)),
+		Request: &http.Request{
+			Method: "POST",
+			URL:    &url.URL{Scheme: "https", Host: "api.bugsnag.com", Path: "/organizations/1/projects"},
+		},
+	}
+
+	err := newAPIError(r)
+
+	want := "POST /organizations/1/projects: Bugsnag API responded with status 422 Unprocessable Entity (request ID abc123): name has already been taken"
+	if err.Error() != want {
+		t.Fatalf("unexpected error message:\n got: %s\nwant: %s", err, want)
+	}
+}
+
+func TestAPIErrorWrapsSentinels(t *testing.T) {
+	r := &http.Response{
+		StatusCode: 404,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	}
+
+	err := newAPIError(r)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if errors.Is(err, ErrConflict) {
+		t.Fatalf("didn't expect ErrConflict")
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestAPIErrorWrapsSentinelsWhenTheBodyCantBeRead(t *testing.T) {
+	r := &http.Response{
+		StatusCode: 404,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(failingReader{}),
+	}
+
+	err := newAPIError(r)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "connection reset") {
+		t.Fatalf("expected the read error to be reported, got %v", err)
+	}
+}
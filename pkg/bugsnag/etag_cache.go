@@ -0,0 +1,94 @@
+package bugsnag
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"sync"
+)
+
+// etagCache keeps the last response to each GET request that came with an
+// ETag, so that refreshing many resources doesn't download payloads that
+// haven't changed: requests are made conditional with If-None-Match, and a
+// 304 Not Modified response is answered from the cache.
+type etagCache struct {
+	mu      sync.Mutex
+	entries map[string]*etagCacheEntry
+}
+
+type etagCacheEntry struct {
+	etag   string
+	header http.Header
+	body   []byte
+}
+
+func newETagCache() *etagCache {
+	return &etagCache{entries: make(map[string]*etagCacheEntry)}
+}
+
+// prepare makes a GET request conditional when a response to it is cached.
+func (c *etagCache) prepare(req *http.Request) {
+	if req.Method != "GET" {
+		return
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[req.URL.String()]
+	c.mu.Unlock()
+
+	if ok {
+		req.Header.Set("If-None-Match", entry.etag)
+	}
+}
+
+// update returns the response to use for req: the cached response when r
+// is a 304 Not Modified, otherwise r itself, which is cached if it has an
+// ETag.
+func (c *etagCache) update(req *http.Request, r *http.Response) (*http.Response, error) {
+	if req.Method != "GET" {
+		return r, nil
+	}
+
+	key := req.URL.String()
+
+	if r.StatusCode == http.StatusNotModified {
+		c.mu.Lock()
+		entry, ok := c.entries[key]
+		c.mu.Unlock()
+
+		if !ok {
+			return r, nil
+		}
+
+		r.Body.Close()
+		return &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         r.Proto,
+			ProtoMajor:    r.ProtoMajor,
+			ProtoMinor:    r.ProtoMinor,
+			Header:        entry.header.Clone(),
+			Body:          ioutil.NopCloser(bytes.NewReader(entry.body)),
+			ContentLength: int64(len(entry.body)),
+			Request:       req,
+		}, nil
+	}
+
+	etag := r.Header.Get("ETag")
+	if r.StatusCode != http.StatusOK || etag == "" {
+		return r, nil
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	c.mu.Lock()
+	c.entries[key] = &etagCacheEntry{etag: etag, header: r.Header.Clone(), body: body}
+	c.mu.Unlock()
+
+	return r, nil
+}
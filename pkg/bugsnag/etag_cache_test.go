@@ -0,0 +1,40 @@
+package bugsnag
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientRevalidatesWithETags(t *testing.T) {
+	downloads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(304)
+			return
+		}
+
+		downloads++
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"id": "1", "name": "api"}`)
+	}))
+	defer server.Close()
+
+	c := NewClient(ClientConfig{BaseURL: server.URL, OrganizationID: "1"})
+
+	for i := 0; i < 3; i++ {
+		project, err := c.GetProject(context.Background(), "1")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if project.Name != "api" {
+			t.Fatalf("unexpected project: %v", project)
+		}
+	}
+
+	if downloads != 1 {
+		t.Fatalf("expected the project to be downloaded once, got %d", downloads)
+	}
+}
@@ -0,0 +1,48 @@
+package bugsnag
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// ListEventFields returns the event fields of a project, by which its
+// events can be filtered: those built into Bugsnag, and the custom ones.
+func (c *Client) ListEventFields(ctx context.Context, projectID string) ([]*EventField, error) {
+	fields := make([]*EventField, 0)
+	// projects have a few dozen fields at most, so a single page holds them
+	url := fmt.Sprintf("%s/projects/%s/event_fields?per_page=100", c.HostURL, projectID)
+	if err := c.doJSON(ctx, "listEventFields", "GET", url, nil, &fields, 200); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+// CreateEventField adds a custom event field to a project.
+func (c *Client) CreateEventField(ctx context.Context, projectID string, field *EventField) (*EventField, error) {
+	created := &EventField{}
+	url := fmt.Sprintf("%s/projects/%s/event_fields", c.HostURL, projectID)
+	if err := c.doJSON(ctx, "createEventField", "POST", url, field, created, 200, 201); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// UpdateEventField replaces the definition of a custom event field.
+func (c *Client) UpdateEventField(ctx context.Context, projectID, displayID string, field *EventField) (*EventField, error) {
+	updated := &EventField{}
+	endpoint := fmt.Sprintf("%s/projects/%s/event_fields/%s", c.HostURL, projectID, url.PathEscape(displayID))
+	if err := c.doJSON(ctx, "updateEventField", "PATCH", endpoint, field, updated, 200); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeleteEventField removes a custom event field from a project.
+func (c *Client) DeleteEventField(ctx context.Context, projectID, displayID string) error {
+	endpoint := fmt.Sprintf("%s/projects/%s/event_fields/%s", c.HostURL, projectID, url.PathEscape(displayID))
+	return c.doJSON(ctx, "deleteEventField", "DELETE", endpoint, nil, nil, 200, 204)
+}
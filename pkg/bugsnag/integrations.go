@@ -0,0 +1,38 @@
+package bugsnag
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// GetGitLabIntegration returns the GitLab integration of a project, or
+// ErrNotFound if it has none.
+func (c *Client) GetGitLabIntegration(ctx context.Context, projectID string) (*GitLabIntegration, error) {
+	integration := &GitLabIntegration{}
+	endpoint := fmt.Sprintf("%s/projects/%s/integrations/gitlab", c.HostURL, url.PathEscape(projectID))
+	if err := c.doJSON(ctx, "getGitLabIntegration", "GET", endpoint, nil, integration, 200); err != nil {
+		return nil, err
+	}
+
+	return integration, nil
+}
+
+// UpdateGitLabIntegration sets the GitLab integration of a project, creating
+// it if the project has none, and returns the integration.
+func (c *Client) UpdateGitLabIntegration(ctx context.Context, projectID string, integration *GitLabIntegration) (*GitLabIntegration, error) {
+	updated := &GitLabIntegration{}
+	endpoint := fmt.Sprintf("%s/projects/%s/integrations/gitlab", c.HostURL, url.PathEscape(projectID))
+	if err := c.doJSON(ctx, "updateGitLabIntegration", "PUT", endpoint, integration, updated, 200, 201); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeleteGitLabIntegration removes the GitLab integration of a project. The
+// issues it created are left in GitLab.
+func (c *Client) DeleteGitLabIntegration(ctx context.Context, projectID string) error {
+	endpoint := fmt.Sprintf("%s/projects/%s/integrations/gitlab", c.HostURL, url.PathEscape(projectID))
+	return c.doJSON(ctx, "deleteGitLabIntegration", "DELETE", endpoint, nil, nil, 200, 204)
+}
@@ -0,0 +1,342 @@
+package bugsnag
+
+import (
+	"fmt"
+	"time"
+)
+
+// Project is a Bugsnag project.
+//
+// Fields tagged required must be present in every response; see
+// checkRequiredFields.
+//
+// https://bugsnagapiv2.docs.apiary.io/#reference/projects/projects/view-a-project
+type Project struct {
+	ID                     string                 `json:"id" required:"true"`
+	OrganizationID         string                 `json:"organization_id"`
+	Name                   string                 `json:"name" required:"true"`
+	Slug                   string                 `json:"slug"`
+	APIKey                 string                 `json:"api_key"`
+	Type                   string                 `json:"type"`
+	IsFullView             bool                   `json:"is_full_view"`
+	ReleaseStages          []string               `json:"release_stages"`
+	Language               string                 `json:"language"`
+	CreatedAt              string                 `json:"created_at"`
+	UpdatedAt              string                 `json:"updated_at"`
+	URL                    string                 `json:"url"`
+	HTMLURL                string                 `json:"html_url"`
+	ErrorsURL              string                 `json:"errors_url"`
+	EventsURL              string                 `json:"events_url"`
+	GlobalGrouping         []string               `json:"global_grouping"`
+	LocationGrouping       []string               `json:"location_grouping"`
+	DiscardedAppVersions   []string               `json:"discarded_app_versions"`
+	DiscardedErrors        []string               `json:"discarded_errors"`
+	URLWhitelist           []string               `json:"url_whitelist"`
+	IgnoreOldBrowsers      bool                   `json:"ignore_old_browsers"`
+	IgnoredBrowserVersions map[string]interface{} `json:"ignored_browser_versions"`
+	ResolveOnDeploy        bool                   `json:"resolve_on_deploy"`
+	OpenErrorCount         int                    `json:"open_error_count"`
+	ForReviewErrorCount    int                    `json:"for_review_error_count"`
+	CollaboratorsCount     int                    `json:"collaborators_count"`
+	CustomEventFieldsUsed  int                    `json:"custom_event_fields_used"`
+
+	// Framework, Platform and NotifierLanguageVersion are detected by
+	// Bugsnag from the events the project receives, and are empty until then.
+	Framework               string `json:"framework"`
+	Platform                string `json:"platform"`
+	NotifierLanguageVersion string `json:"notifier_language_version"`
+
+	// CollaboratorsCanModifySettings is whether collaborators who aren't
+	// organization admins may change the settings of the project.
+	CollaboratorsCanModifySettings bool `json:"collaborators_can_modify_settings"`
+}
+
+// String identifies the project without its notifier API key, so that
+// printing a project in an error or a log never leaks the key.
+func (p *Project) String() string {
+	return fmt.Sprintf("project %s (%s)", p.ID, p.Name)
+}
+
+// CreateProjectRequest holds the attributes of a new project.
+type CreateProjectRequest struct {
+	Name              string   `json:"name"`
+	Type              string   `json:"type"`
+	Language          string   `json:"language,omitempty"`
+	IgnoreOldBrowsers *bool    `json:"ignore_old_browsers,omitempty"`
+	ResolveOnDeploy   *bool    `json:"resolve_on_deploy,omitempty"`
+
+	// The lists are pointers so that a project can be created without any
+	// release stage, whitelisted URL or grouping rule. Nil lists start with
+	// the API's defaults.
+	ReleaseStages    *[]string `json:"release_stages,omitempty"`
+	URLWhitelist     *[]string `json:"url_whitelist,omitempty"`
+	GlobalGrouping   *[]string `json:"global_grouping,omitempty"`
+	LocationGrouping *[]string `json:"location_grouping,omitempty"`
+
+	CollaboratorsCanModifySettings *bool `json:"collaborators_can_modify_settings,omitempty"`
+
+	// The settings served apart from the project can be given on creation
+	// too, which saves a request each. Nil settings start with their
+	// defaults.
+	EmailNotifications *EmailNotificationSettings `json:"email_notifications,omitempty"`
+	ReopenRules        *ReopenRules               `json:"reopen_rules,omitempty"`
+	SpikeDetection     *SpikeDetectionSettings    `json:"spike_detection,omitempty"`
+	StabilityTargets   *StabilityTargets          `json:"stability_targets,omitempty"`
+}
+
+// UpdateProjectRequest holds the attributes of a project to change. Nil
+// fields are left unchanged.
+type UpdateProjectRequest struct {
+	Name              *string `json:"name,omitempty"`
+	Language          *string `json:"language,omitempty"`
+	IgnoreOldBrowsers *bool   `json:"ignore_old_browsers,omitempty"`
+	ResolveOnDeploy   *bool   `json:"resolve_on_deploy,omitempty"`
+
+	// ReleaseStages is a pointer so that every release stage can be removed.
+	ReleaseStages *[]string `json:"release_stages,omitempty"`
+
+	// URLWhitelist is a pointer so that the whitelist can be emptied, which
+	// allows errors from every URL again.
+	URLWhitelist *[]string `json:"url_whitelist,omitempty"`
+
+	// GlobalGrouping is a pointer so that every grouping override can be
+	// removed.
+	GlobalGrouping *[]string `json:"global_grouping,omitempty"`
+
+	// LocationGrouping is a pointer for the same reason as GlobalGrouping.
+	LocationGrouping *[]string `json:"location_grouping,omitempty"`
+
+	// DiscardedAppVersions and DiscardedErrors are pointers so that events
+	// can stop being discarded.
+	DiscardedAppVersions *[]string `json:"discarded_app_versions,omitempty"`
+	DiscardedErrors      *[]string `json:"discarded_errors,omitempty"`
+
+	CollaboratorsCanModifySettings *bool `json:"collaborators_can_modify_settings,omitempty"`
+}
+
+// EmailNotificationSettings are the errors of a project Bugsnag sends
+// emails about.
+type EmailNotificationSettings struct {
+	NewErrors      bool `json:"new_errors"`
+	ReopenedErrors bool `json:"reopened_errors"`
+	ErrorSpikes    bool `json:"error_spikes"`
+	DailySummary   bool `json:"daily_summary"`
+}
+
+// ReopenRules reopen the resolved errors of a project that occur Occurrences
+// times within Hours, when Enabled.
+type ReopenRules struct {
+	Enabled     bool `json:"enabled"`
+	Occurrences int  `json:"occurrences"`
+	Hours       int  `json:"hours"`
+}
+
+// SpikeDetectionSettings are when Bugsnag considers an error of a project to
+// be spiking: when it occurs ThresholdMultiplier times more often than usual
+// over WindowMinutes, and at least MinimumEvents times.
+type SpikeDetectionSettings struct {
+	Enabled             bool    `json:"enabled"`
+	ThresholdMultiplier float64 `json:"threshold_multiplier"`
+	MinimumEvents       int     `json:"minimum_events"`
+	WindowMinutes       int     `json:"window_minutes"`
+	NotifyIntegrations  bool    `json:"notify_integrations"`
+}
+
+// StabilityTargets are the percentages of sessions, or users, free of
+// unhandled errors a project aims for and below which its stability is
+// critical. Only the targets set are updated.
+type StabilityTargets struct {
+	TargetStability   *float64 `json:"target_stability,omitempty"`
+	CriticalStability *float64 `json:"critical_stability,omitempty"`
+}
+
+// ProjectStability is the current percentage of users, and of sessions, of
+// a project free of unhandled errors. Both are nil until the project has
+// sessions.
+type ProjectStability struct {
+	UserStability    *float64 `json:"user_stability"`
+	SessionStability *float64 `json:"session_stability"`
+}
+
+// EventUsage is the number of events a project received in the current
+// billing period, against the number of events allocated to it. EventQuota
+// is nil for a project with no allocation of its own.
+type EventUsage struct {
+	EventsUsed int64  `json:"events_used"`
+	EventQuota *int64 `json:"event_quota"`
+}
+
+// EventField is a field by which the events of a project can be filtered.
+// Custom fields are read from the event metadata at Path.
+type EventField struct {
+	DisplayID     string                  `json:"display_id" required:"true"`
+	Custom        bool                    `json:"custom"`
+	Path          string                  `json:"path,omitempty"`
+	FilterOptions EventFieldFilterOptions `json:"filter_options"`
+	// PivotOptions is set when events can also be grouped by the field. It
+	// is sent even when nil, so that updating a field can stop it being a
+	// pivot.
+	PivotOptions *EventFieldPivotOptions `json:"pivot_options"`
+}
+
+// EventFieldFilterOptions are how an event field is shown in filters.
+type EventFieldFilterOptions struct {
+	Name string `json:"name"`
+}
+
+// EventFieldPivotOptions are how an event field is shown as a pivot.
+type EventFieldPivotOptions struct {
+	Name string `json:"name,omitempty"`
+}
+
+// Collaborator is a member of the organization, or someone invited to it.
+type Collaborator struct {
+	ID    string `json:"id" required:"true"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	// IsAdmin collaborators manage the organization, and access every
+	// project regardless of ProjectIDs.
+	IsAdmin    bool     `json:"is_admin"`
+	ProjectIDs []string `json:"project_ids"`
+	// PendingInvitation is set until the collaborator accepts the
+	// invitation to the organization.
+	PendingInvitation bool `json:"pending_invitation"`
+}
+
+// InviteCollaboratorRequest holds the permissions of a collaborator to
+// invite.
+type InviteCollaboratorRequest struct {
+	Email      string   `json:"email"`
+	Admin      bool     `json:"admin"`
+	ProjectIDs []string `json:"project_ids"`
+}
+
+// UpdateCollaboratorRequest holds the permissions of a collaborator to
+// change. Nil fields are left unchanged.
+type UpdateCollaboratorRequest struct {
+	Admin *bool `json:"admin,omitempty"`
+	// ProjectIDs replaces the projects the collaborator can access.
+	ProjectIDs *[]string `json:"project_ids,omitempty"`
+}
+
+// projectAPIKey is the response to a project's notifier API key being
+// regenerated.
+type projectAPIKey struct {
+	APIKey string `json:"api_key" required:"true"`
+}
+
+// AccessToken is a short-lived data access token issued for the
+// organization.
+type AccessToken struct {
+	ID        string    `json:"id"`
+	Token     string    `json:"token" required:"true"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Permission levels a team can be given on a project.
+const (
+	// TeamPermissionMember lets the members of the team triage the errors of
+	// the project.
+	TeamPermissionMember = "member"
+	// TeamPermissionAdmin lets the members of the team change the settings
+	// of the project too.
+	TeamPermissionAdmin = "admin"
+)
+
+// TeamProject is the access the members of a team have to a project.
+type TeamProject struct {
+	TeamID     string `json:"team_id" required:"true"`
+	ProjectID  string `json:"project_id" required:"true"`
+	Permission string `json:"permission"`
+}
+
+// AllowedEmailDomain is an email domain, e.g. example.com, that people can
+// be invited to the organization with. Once the organization has any, people
+// can only be invited with an email of one of them.
+type AllowedEmailDomain struct {
+	Domain string `json:"domain" required:"true"`
+}
+
+// Roles people who sign in with SSO for the first time join the organization
+// with.
+const (
+	// SSORoleCollaborator joins people as collaborators with access to no
+	// project, until they are given access to some.
+	SSORoleCollaborator = "collaborator"
+	// SSORoleAdmin joins people as admins of the organization.
+	SSORoleAdmin = "admin"
+)
+
+// SSOConfiguration is how the members of the organization sign in with SAML.
+type SSOConfiguration struct {
+	// IdPMetadataURL is the URL of the SAML metadata of the identity
+	// provider.
+	IdPMetadataURL string `json:"idp_metadata_url"`
+	// Enforced stops members signing in with a password, so that they can
+	// only sign in with SSO.
+	Enforced    bool   `json:"enforced"`
+	DefaultRole string `json:"default_role,omitempty"`
+
+	// The service provider details to configure the identity provider
+	// with, set by Bugsnag.
+	EntityID string `json:"entity_id,omitempty"`
+	ACSURL   string `json:"acs_url,omitempty"`
+}
+
+// Events of a project that a webhook can be triggered by.
+const (
+	WebhookTriggerEveryEvent    = "every_event"
+	WebhookTriggerNewError      = "new_error"
+	WebhookTriggerReopenedError = "reopened_error"
+	WebhookTriggerErrorSpike    = "error_spike"
+	WebhookTriggerComment       = "comment"
+)
+
+// WebhookTriggers are every event a webhook can be triggered by.
+var WebhookTriggers = []string{
+	WebhookTriggerEveryEvent,
+	WebhookTriggerNewError,
+	WebhookTriggerReopenedError,
+	WebhookTriggerErrorSpike,
+	WebhookTriggerComment,
+}
+
+// Webhook forwards events of a project to a URL as they happen. Bugsnag
+// never returns its secret.
+type Webhook struct {
+	ID        string   `json:"id" required:"true"`
+	ProjectID string   `json:"project_id"`
+	URL       string   `json:"url"`
+	Triggers  []string `json:"triggers"`
+}
+
+// CreateWebhookRequest holds the attributes of a new webhook. Bugsnag signs
+// the requests of a webhook with a Secret with an HMAC signature, so that
+// the receiver can check that they come from Bugsnag.
+type CreateWebhookRequest struct {
+	URL      string   `json:"url"`
+	Secret   string   `json:"secret,omitempty"`
+	Triggers []string `json:"triggers"`
+}
+
+// UpdateWebhookRequest holds the attributes of a webhook to change. Nil
+// fields are left unchanged; an empty Secret stops requests being signed.
+type UpdateWebhookRequest struct {
+	URL      *string   `json:"url,omitempty"`
+	Secret   *string   `json:"secret,omitempty"`
+	Triggers *[]string `json:"triggers,omitempty"`
+}
+
+// GitLabIntegration creates issues in a GitLab project from the errors of a
+// Bugsnag project. Bugsnag never returns its Token.
+type GitLabIntegration struct {
+	// InstanceURL is the URL of the GitLab instance, e.g. https://gitlab.com
+	// or that of a self-hosted instance.
+	InstanceURL string `json:"instance_url"`
+	// ProjectPath is the path of the GitLab project issues are created in,
+	// e.g. group/subgroup/project.
+	ProjectPath string `json:"project_path"`
+	// Token is the GitLab access token issues are created with. An empty
+	// Token leaves the current one unchanged.
+	Token string `json:"token,omitempty"`
+}
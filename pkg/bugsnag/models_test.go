@@ -0,0 +1,17 @@
+package bugsnag
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestProjectStringOmitsAPIKey(t *testing.T) {
+	project := &Project{ID: "p1", Name: "web", APIKey: "a1b2c3d4e5f60718293a4b5c6d7e8f90"}
+
+	for _, format := range []string{"%v", "%+v", "%s"} {
+		if s := fmt.Sprintf(format, project); strings.Contains(s, project.APIKey) {
+			t.Errorf("expected %s not to print the API key, got %q", format, s)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package bugsnag
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetEmailNotificationSettings returns which errors of a project Bugsnag
+// sends emails about.
+func (c *Client) GetEmailNotificationSettings(ctx context.Context, projectID string) (*EmailNotificationSettings, error) {
+	settings := &EmailNotificationSettings{}
+	url := fmt.Sprintf("%s/projects/%s/email_notifications", c.HostURL, projectID)
+	if err := c.doJSON(ctx, "getEmailNotificationSettings", "GET", url, nil, settings, 200); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// UpdateEmailNotificationSettings replaces the email notification settings
+// of a project, and returns them as updated.
+func (c *Client) UpdateEmailNotificationSettings(ctx context.Context, projectID string, settings *EmailNotificationSettings) (*EmailNotificationSettings, error) {
+	updated := &EmailNotificationSettings{}
+	url := fmt.Sprintf("%s/projects/%s/email_notifications", c.HostURL, projectID)
+	if err := c.doJSON(ctx, "updateEmailNotificationSettings", "PATCH", url, settings, updated, 200); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
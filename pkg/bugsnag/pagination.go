@@ -0,0 +1,53 @@
+package bugsnag
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// nextPageURL returns the URL of the next page of a paginated list response,
+// taken from its Link header, or "" on the last page.
+//
+// https://bugsnagapiv2.docs.apiary.io/#introduction/pagination
+func nextPageURL(r *http.Response) (string, error) {
+	for _, header := range r.Header.Values("Link") {
+		for _, link := range strings.Split(header, ",") {
+			target, params, ok := strings.Cut(strings.TrimSpace(link), ";")
+			if !ok || !isNextRel(params) {
+				continue
+			}
+
+			target = strings.TrimSpace(target)
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				return "", fmt.Errorf("malformed Link header: %q", header)
+			}
+
+			next, err := r.Request.URL.Parse(strings.Trim(target, "<>"))
+			if err != nil {
+				return "", fmt.Errorf("malformed Link header: %q: %w", header, err)
+			}
+			return next.String(), nil
+		}
+	}
+
+	return "", nil
+}
+
+// isNextRel reports whether the parameters of a link include rel="next".
+func isNextRel(params string) bool {
+	for _, param := range strings.Split(params, ";") {
+		key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok || !strings.EqualFold(strings.TrimSpace(key), "rel") {
+			continue
+		}
+
+		for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(value), `"`)) {
+			if strings.EqualFold(rel, "next") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,92 @@
+package bugsnag
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestNextPageURL(t *testing.T) {
+	base, _ := url.Parse("https://api.bugsnag.com/organizations/1/projects?per_page=100")
+
+	cases := map[string]string{
+		"": "",
+		`<https://api.bugsnag.com/organizations/1/projects?offset=100&per_page=100>; rel="next"`: "https://api.bugsnag.com/organizations/1/projects?offset=100&per_page=100",
+		`</organizations/1/projects?offset=100>; rel="next"`:                                     "https://api.bugsnag.com/organizations/1/projects?offset=100",
+		`<https://api.bugsnag.com/a>; rel="prev", <https://api.bugsnag.com/b>; rel="next"`:       "https://api.bugsnag.com/b",
+		`<https://api.bugsnag.com/a>; rel="prev"`:                                                "",
+	}
+
+	for header, want := range cases {
+		r := &http.Response{Header: http.Header{}, Request: &http.Request{URL: base}}
+		if header != "" {
+			r.Header.Set("Link", header)
+		}
+
+		got, err := nextPageURL(r)
+		if err != nil {
+			t.Errorf("nextPageURL(%q) returned an error: %s", header, err)
+		}
+		if got != want {
+			t.Errorf("nextPageURL(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestClientListsEveryPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("offset") {
+		case "":
+			w.Header().Set("Link", `</organizations/1/projects?offset=1&per_page=100>; rel="next"`)
+			fmt.Fprint(w, `[{"id": "1", "name": "api"}]`)
+		case "1":
+			w.Header().Set("Link", `</organizations/1/projects?offset=2&per_page=100>; rel="next"`)
+			fmt.Fprint(w, `[{"id": "2", "name": "web"}]`)
+		default:
+			fmt.Fprint(w, `[{"id": "3", "name": "ios"}]`)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(ClientConfig{BaseURL: server.URL, OrganizationID: "1"})
+
+	projects, err := c.ListProjects(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(projects) != 3 {
+		t.Fatalf("expected 3 projects, got %d", len(projects))
+	}
+}
+
+func TestIterateProjectsFetchesPagesLazily(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Query().Get("offset") {
+		case "":
+			w.Header().Set("Link", `</organizations/1/projects?offset=2&per_page=100>; rel="next"`)
+			fmt.Fprint(w, `[{"id": "1", "name": "api"}, {"id": "2", "name": "web"}]`)
+		default:
+			fmt.Fprint(w, `[{"id": "3", "name": "ios"}]`)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(ClientConfig{BaseURL: server.URL, OrganizationID: "1"})
+
+	for project, err := range c.IterateProjects(context.Background()) {
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if project.Name == "web" {
+			break
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("expected only the first page to be fetched, got %d requests", requests)
+	}
+}
@@ -0,0 +1,148 @@
+package bugsnag
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket shared by every request a Client sends, so
+// resources and data sources refreshed in parallel stay within one budget.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+}
+
+// newRateLimiter returns a limiter allowing requestsPerMinute requests per
+// minute, or nil (no limit) when requestsPerMinute is not positive.
+func newRateLimiter(requestsPerMinute int) *rateLimiter {
+	if requestsPerMinute <= 0 {
+		return nil
+	}
+
+	burst := float64(requestsPerMinute / 10)
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &rateLimiter{
+		interval: time.Minute / time.Duration(requestsPerMinute),
+		burst:    burst,
+		tokens:   burst,
+		last:     time.Now(),
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done. A nil limiter never
+// blocks.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	return sleepContext(ctx, l.reserve(time.Now()))
+}
+
+// reserve takes a token, returning how long the caller must wait before the
+// token becomes valid.
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens * float64(l.interval))
+}
+
+// rateLimitTracker records the remaining quota the API reports through the
+// X-RateLimit-Remaining and X-RateLimit-Reset response headers.
+type rateLimitTracker struct {
+	mu        sync.Mutex
+	threshold int
+	known     bool
+	remaining int
+	reset     time.Time
+	warned    bool
+
+	// peak is the largest share of the quota seen in use, when the API
+	// reports the limit through X-RateLimit-Limit.
+	peak      float64
+	peakKnown bool
+}
+
+func newRateLimitTracker(threshold int) *rateLimitTracker {
+	return &rateLimitTracker{threshold: threshold}
+}
+
+// observe updates the tracked quota from a response's headers.
+func (t *rateLimitTracker) observe(h http.Header) {
+	if t == nil {
+		return
+	}
+
+	remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.known = true
+	t.remaining = remaining
+	if reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		t.reset = time.Unix(reset, 0)
+	}
+	if remaining > t.threshold {
+		t.warned = false
+	}
+	if limit, err := strconv.Atoi(h.Get("X-RateLimit-Limit")); err == nil && limit > 0 {
+		if usage := float64(limit-remaining) / float64(limit); !t.peakKnown || usage > t.peak {
+			t.peak, t.peakKnown = usage, true
+		}
+	}
+}
+
+// peakUsage returns the largest share of the rate limit quota seen in use.
+func (t *rateLimitTracker) peakUsage() (float64, bool) {
+	if t == nil {
+		return 0, false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.peak, t.peakKnown
+}
+
+// warning returns the remaining quota and when it resets the first time it
+// drops to or below the threshold. It stays silent until the quota recovers
+// again.
+func (t *rateLimitTracker) warning() (remaining int, reset time.Time, ok bool) {
+	if t == nil {
+		return 0, time.Time{}, false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.known || t.threshold <= 0 || t.remaining > t.threshold || t.warned {
+		return 0, time.Time{}, false
+	}
+	t.warned = true
+
+	return t.remaining, t.reset, true
+}
@@ -0,0 +1,60 @@
+package bugsnag
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterReserve(t *testing.T) {
+	l := newRateLimiter(5)
+	now := l.last
+
+	if wait := l.reserve(now); wait != 0 {
+		t.Fatalf("expected the first request to be allowed immediately, got %s", wait)
+	}
+	if wait := l.reserve(now); wait != 12*time.Second {
+		t.Fatalf("expected the second request to wait 12s, got %s", wait)
+	}
+	if wait := l.reserve(now); wait != 24*time.Second {
+		t.Fatalf("expected the third request to wait 24s, got %s", wait)
+	}
+	if wait := l.reserve(now.Add(time.Minute)); wait != 0 {
+		t.Fatalf("expected tokens to refill over time, got %s", wait)
+	}
+}
+
+func TestRateLimiterDisabled(t *testing.T) {
+	if l := newRateLimiter(0); l != nil {
+		t.Fatalf("expected no limiter when requests per minute is 0")
+	}
+}
+
+func TestRateLimitTrackerWarnsOnce(t *testing.T) {
+	tracker := newRateLimitTracker(5)
+
+	observe := func(remaining string) {
+		tracker.observe(http.Header{"X-Ratelimit-Remaining": []string{remaining}})
+	}
+
+	observe("50")
+	if _, _, ok := tracker.warning(); ok {
+		t.Fatalf("expected no warning with plenty of quota left")
+	}
+
+	observe("3")
+	if remaining, _, ok := tracker.warning(); !ok || remaining != 3 {
+		t.Fatalf("expected a warning once quota is low, got %d remaining (%v)", remaining, ok)
+	}
+
+	observe("2")
+	if _, _, ok := tracker.warning(); ok {
+		t.Fatalf("expected the warning to be raised only once")
+	}
+
+	observe("60")
+	observe("1")
+	if _, _, ok := tracker.warning(); !ok {
+		t.Fatalf("expected a new warning after the quota recovered")
+	}
+}
@@ -0,0 +1,29 @@
+package bugsnag
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetReopenRules returns when resolved errors of a project are reopened.
+func (c *Client) GetReopenRules(ctx context.Context, projectID string) (*ReopenRules, error) {
+	rules := &ReopenRules{}
+	url := fmt.Sprintf("%s/projects/%s/reopen_rules", c.HostURL, projectID)
+	if err := c.doJSON(ctx, "getReopenRules", "GET", url, nil, rules, 200); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// UpdateReopenRules replaces the reopen rules of a project, and returns them
+// as updated.
+func (c *Client) UpdateReopenRules(ctx context.Context, projectID string, rules *ReopenRules) (*ReopenRules, error) {
+	updated := &ReopenRules{}
+	url := fmt.Sprintf("%s/projects/%s/reopen_rules", c.HostURL, projectID)
+	if err := c.doJSON(ctx, "updateReopenRules", "PATCH", url, rules, updated, 200); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
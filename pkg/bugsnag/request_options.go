@@ -0,0 +1,60 @@
+package bugsnag
+
+import (
+	"context"
+	"time"
+)
+
+// RequestOptions override the client's timeout and retry policy for the
+// operations made with a context, e.g. for projects behind a slower
+// gateway. Zero durations and nil retries keep the client's settings; the
+// retries are pointers so that retrying can be turned off with 0.
+type RequestOptions struct {
+	RequestTimeout      time.Duration
+	MaxRetries          *int
+	MaxRateLimitRetries *int
+	MaxRetryElapsedTime time.Duration
+}
+
+type requestOptionsKey struct{}
+
+// WithRequestOptions returns a context in which the client's operations use
+// the given options.
+func WithRequestOptions(ctx context.Context, options RequestOptions) context.Context {
+	return context.WithValue(ctx, requestOptionsKey{}, options)
+}
+
+// requestOptions returns the options in effect for the operations made with
+// ctx: those set with WithRequestOptions, falling back to the client's.
+func (c *Client) requestOptions(ctx context.Context) requestPolicy {
+	options, _ := ctx.Value(requestOptionsKey{}).(RequestOptions)
+
+	policy := requestPolicy{
+		RequestTimeout:      options.RequestTimeout,
+		MaxRetries:          c.MaxRetries,
+		MaxRateLimitRetries: c.MaxRateLimitRetries,
+		MaxRetryElapsedTime: options.MaxRetryElapsedTime,
+	}
+	if policy.RequestTimeout <= 0 {
+		policy.RequestTimeout = c.RequestTimeout
+	}
+	if options.MaxRetries != nil && *options.MaxRetries >= 0 {
+		policy.MaxRetries = *options.MaxRetries
+	}
+	if options.MaxRateLimitRetries != nil && *options.MaxRateLimitRetries >= 0 {
+		policy.MaxRateLimitRetries = *options.MaxRateLimitRetries
+	}
+	if policy.MaxRetryElapsedTime <= 0 {
+		policy.MaxRetryElapsedTime = c.MaxRetryElapsedTime
+	}
+
+	return policy
+}
+
+// requestPolicy is the timeout and retry policy a request is made with.
+type requestPolicy struct {
+	RequestTimeout      time.Duration
+	MaxRetries          int
+	MaxRateLimitRetries int
+	MaxRetryElapsedTime time.Duration
+}
@@ -0,0 +1,69 @@
+package bugsnag
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRequestOptionsOverrideTheTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		fmt.Fprint(w, `{"id": "1", "name": "api"}`)
+	}))
+	defer server.Close()
+
+	c := NewClient(ClientConfig{BaseURL: server.URL, OrganizationID: "1", RequestTimeout: 10 * time.Millisecond})
+
+	if _, err := c.GetProject(context.Background(), "1"); err == nil {
+		t.Fatalf("expected the request to time out")
+	}
+
+	ctx := WithRequestOptions(context.Background(), RequestOptions{RequestTimeout: time.Second})
+	if _, err := c.GetProject(ctx, "1"); err != nil {
+		t.Fatalf("expected the longer timeout to be used, got %s", err)
+	}
+}
+
+func TestRequestOptionsOverrideTheRetryPolicy(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	c := NewClient(ClientConfig{BaseURL: server.URL, OrganizationID: "1"})
+
+	retries := 1
+	ctx := WithRequestOptions(context.Background(), RequestOptions{MaxRetries: &retries})
+	if _, err := c.GetProject(ctx, "1"); err == nil {
+		t.Fatalf("expected an error")
+	}
+	if hits != 2 {
+		t.Fatalf("expected 2 attempts, got %d", hits)
+	}
+}
+
+func TestRequestOptionsTurnOffRetries(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	c := NewClient(ClientConfig{BaseURL: server.URL, OrganizationID: "1", MaxRetries: 3})
+
+	retries := 0
+	ctx := WithRequestOptions(context.Background(), RequestOptions{MaxRetries: &retries})
+	if _, err := c.GetProject(ctx, "1"); err == nil {
+		t.Fatalf("expected an error")
+	}
+	if hits != 1 {
+		t.Fatalf("expected a single attempt, got %d", hits)
+	}
+}
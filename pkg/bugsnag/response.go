@@ -0,0 +1,196 @@
+package bugsnag
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// DefaultMaxResponseSize is the largest response body, once decompressed,
+// the client reads when MaxResponseSize is not configured.
+const DefaultMaxResponseSize = 64 << 20
+
+// limitedBody fails reads once more than max bytes were read from body, so
+// that a runaway response can't exhaust the memory.
+type limitedBody struct {
+	body io.ReadCloser
+	max  int64
+	read int64
+}
+
+func limitBody(r *http.Response, max int64) {
+	r.Body = &limitedBody{body: r.Body, max: max}
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.read > b.max {
+		return 0, fmt.Errorf("response body exceeds the maximum size of %d bytes", b.max)
+	}
+
+	// read at most one byte past the limit to tell a body of exactly max
+	// bytes from a larger one
+	if remaining := b.max + 1 - b.read; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+
+	n, err := b.body.Read(p)
+	b.read += int64(n)
+	if b.read > b.max {
+		return n, fmt.Errorf("response body exceeds the maximum size of %d bytes", b.max)
+	}
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	return b.body.Close()
+}
+
+// decodeJSON decodes the JSON body of r into v. Bodies that aren't a single
+// JSON value, e.g. an HTML error page from a proxy, are reported with the
+// start of the body rather than a bare unmarshalling error.
+func decodeJSON(r *http.Response, v interface{}) error {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("%s: reading the response body: %w", requestDescription(r), err)
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType == "text/html" {
+		return fmt.Errorf("%s: expected a JSON response, got %s: %s", requestDescription(r), contentType, bodySnippet(body))
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if err := decoder.Decode(v); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			return &ResponseSchemaError{
+				Endpoint: requestDescription(r),
+				Field:    typeErr.Field,
+				Problem:  fmt.Sprintf("should be %s, got a JSON %s", jsonTypeName(typeErr.Type), typeErr.Value),
+			}
+		}
+		return fmt.Errorf("%s: decoding the response body: %w: %s", requestDescription(r), err, bodySnippet(body))
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return fmt.Errorf("%s: unexpected data after the JSON response body: %s", requestDescription(r), bodySnippet(body))
+	}
+
+	if field, ok := checkRequiredFields(body, v); !ok {
+		return &ResponseSchemaError{
+			Endpoint: requestDescription(r),
+			Field:    field,
+			Problem:  "is missing",
+		}
+	}
+
+	return nil
+}
+
+// ResponseSchemaError is returned for a response body that is valid JSON
+// but doesn't match the model the client decodes it into, naming the field
+// at fault so that API changes can be told apart from other failures.
+type ResponseSchemaError struct {
+	// Endpoint is the method and path of the request, e.g.
+	// "GET /organizations/1/projects".
+	Endpoint string
+
+	// Field is the path of the field in the response, e.g. "name" or
+	// "[3].name".
+	Field string
+
+	// Problem describes what is wrong with the field.
+	Problem string
+}
+
+func (e *ResponseSchemaError) Error() string {
+	return fmt.Sprintf("%s: the %q field of the response %s", e.Endpoint, e.Field, e.Problem)
+}
+
+func (e *ResponseSchemaError) Unwrap() error {
+	return ErrUnexpectedResponse
+}
+
+// checkRequiredFields reports whether the JSON body includes every field
+// tagged `required:"true"` in the model v, which is a struct or a slice of
+// structs, or pointers to them. Otherwise it returns the path of the first
+// missing field.
+func checkRequiredFields(body []byte, v interface{}) (string, bool) {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Slice:
+		var elements []json.RawMessage
+		if err := json.Unmarshal(body, &elements); err != nil {
+			return "", true
+		}
+
+		elem := reflect.New(t.Elem()).Interface()
+		for i, element := range elements {
+			if field, ok := checkRequiredFields(element, elem); !ok {
+				return fmt.Sprintf("[%d].%s", i, field), false
+			}
+		}
+	case reflect.Struct:
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(body, &fields); err != nil || fields == nil {
+			return "", true
+		}
+
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).Tag.Get("required") != "true" {
+				continue
+			}
+			name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+			if _, ok := fields[name]; !ok {
+				return name, false
+			}
+		}
+	}
+
+	return "", true
+}
+
+// jsonTypeName describes the JSON value a Go type is decoded from.
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Map, reflect.Struct:
+		return "an object"
+	}
+	return t.String()
+}
+
+// requestDescription describes the request r is the response to.
+func requestDescription(r *http.Response) string {
+	if r.Request == nil {
+		return "Bugsnag API"
+	}
+	return fmt.Sprintf("%s %s", r.Request.Method, r.Request.URL.Path)
+}
+
+// bodySnippet returns the start of a response body for error messages.
+func bodySnippet(body []byte) string {
+	const max = 200
+	if len(body) > max {
+		return fmt.Sprintf("%q...", body[:max])
+	}
+	return fmt.Sprintf("%q", body)
+}
@@ -0,0 +1,114 @@
+package bugsnag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClientLimitsResponseSize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id": "1", "name": "%s"}`, strings.Repeat("a", 1024))
+	}))
+	defer server.Close()
+
+	c := NewClient(ClientConfig{BaseURL: server.URL, OrganizationID: "1", MaxResponseSize: 512})
+
+	_, err := c.GetProject(context.Background(), "1")
+	if err == nil || !strings.Contains(err.Error(), "maximum size") {
+		t.Fatalf("expected the response to be rejected as too large, got %v", err)
+	}
+
+	c = NewClient(ClientConfig{BaseURL: server.URL, OrganizationID: "1", MaxResponseSize: 2048})
+
+	if _, err := c.GetProject(context.Background(), "1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestClientRejectsMalformedResponses(t *testing.T) {
+	cases := map[string]struct {
+		contentType string
+		body        string
+	}{
+		"html page":     {"text/html; charset=utf-8", "<html><body>502 Bad Gateway</body></html>"},
+		"invalid json":  {"application/json", `{"id": "1",`},
+		"trailing data": {"application/json", `{"id": "1"} {"id": "2"}`},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tc.contentType)
+				fmt.Fprint(w, tc.body)
+			}))
+			defer server.Close()
+
+			c := NewClient(ClientConfig{BaseURL: server.URL, OrganizationID: "1"})
+
+			_, err := c.GetProject(context.Background(), "1")
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if !strings.Contains(err.Error(), "GET /organizations/1/projects/1") {
+				t.Fatalf("expected the error to name the request, got %s", err)
+			}
+		})
+	}
+}
+
+func TestClientReportsResponseSchemaMismatches(t *testing.T) {
+	cases := map[string]struct {
+		body    string
+		field   string
+		problem string
+	}{
+		"missing field":     {`{"id": "1", "project_name": "api"}`, "name", "is missing"},
+		"mistyped field":    {`{"id": "1", "name": "api", "open_error_count": "3"}`, "open_error_count", "should be a number, got a JSON string"},
+		"mistyped in array": {`{"id": "1", "name": "api", "release_stages": "production"}`, "release_stages", "should be an array, got a JSON string"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, tc.body)
+			}))
+			defer server.Close()
+
+			c := NewClient(ClientConfig{BaseURL: server.URL, OrganizationID: "1"})
+
+			_, err := c.GetProject(context.Background(), "1")
+			if !errors.Is(err, ErrUnexpectedResponse) {
+				t.Fatalf("expected ErrUnexpectedResponse, got %v", err)
+			}
+
+			var schemaErr *ResponseSchemaError
+			if !errors.As(err, &schemaErr) {
+				t.Fatalf("expected a *ResponseSchemaError, got %T", err)
+			}
+			if schemaErr.Endpoint != "GET /organizations/1/projects/1" || schemaErr.Field != tc.field || schemaErr.Problem != tc.problem {
+				t.Fatalf("unexpected error: %+v", schemaErr)
+			}
+		})
+	}
+}
+
+func TestClientNamesMissingFieldsInLists(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": "1", "name": "api"}, {"id": "2"}]`)
+	}))
+	defer server.Close()
+
+	c := NewClient(ClientConfig{BaseURL: server.URL, OrganizationID: "1"})
+
+	_, err := c.ListProjects(context.Background())
+
+	var schemaErr *ResponseSchemaError
+	if !errors.As(err, &schemaErr) || schemaErr.Field != "[1].name" {
+		t.Fatalf("expected the missing name of the second project to be reported, got %v", err)
+	}
+}
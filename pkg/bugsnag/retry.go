@@ -0,0 +1,127 @@
+package bugsnag
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// defaultRetryAfter is used when a 429 response carries no usable
+	// Retry-After header.
+	defaultRetryAfter = 5 * time.Second
+
+	// maxRetryAfter caps how long a single rate limit wait may last, so a
+	// misbehaving header can't stall an apply indefinitely.
+	maxRetryAfter = time.Minute
+
+	// retryBaseDelay and retryMaxDelay bound the exponential backoff used
+	// between retries of transient server errors.
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 30 * time.Second
+)
+
+// isTransientStatus reports whether a response status indicates a temporary
+// server-side problem that is worth retrying.
+func isTransientStatus(status int) bool {
+	switch status {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// idempotencyKeyHeader carries a key identifying a create request, so the
+// API can recognise a retried create and return the original result instead
+// of creating a duplicate.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// isIdempotent reports whether a request can be sent again without side
+// effects: either its method is idempotent, or it carries an idempotency key.
+func isIdempotent(req *http.Request) bool {
+	if req.Header.Get(idempotencyKeyHeader) != "" {
+		return true
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// setIdempotencyKey gives a create request a fresh idempotency key. The key
+// stays on the request across retries, so every attempt counts as the same
+// create.
+func setIdempotencyKey(req *http.Request) {
+	req.Header.Set(idempotencyKeyHeader, randomHex(16))
+}
+
+// backoff returns the wait before the given retry (counting from zero): an
+// exponentially growing delay with jitter, capped at retryMaxDelay.
+func backoff(retry int) time.Duration {
+	delay := retryMaxDelay
+	if retry < 16 {
+		if d := retryBaseDelay << uint(retry); d < retryMaxDelay {
+			delay = d
+		}
+	}
+
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// parseRetryAfter returns how long to wait before retrying, given the value
+// of a Retry-After header in either its delay-seconds or HTTP-date form.
+func parseRetryAfter(header string, now time.Time) time.Duration {
+	if header == "" {
+		return defaultRetryAfter
+	}
+
+	var wait time.Duration
+	if seconds, err := strconv.Atoi(header); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(header); err == nil {
+		wait = date.Sub(now)
+	} else {
+		return defaultRetryAfter
+	}
+
+	if wait < 0 {
+		return 0
+	}
+	if wait > maxRetryAfter {
+		return maxRetryAfter
+	}
+	return wait
+}
+
+// sleepContext waits for d, returning early with the context's error if it is
+// cancelled or its deadline passes first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// rewindBody resets the request body so the request can be sent again.
+func rewindBody(req *http.Request) error {
+	if req.Body == nil || req.GetBody == nil {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	return nil
+}
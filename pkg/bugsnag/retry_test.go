@@ -0,0 +1,184 @@
+package bugsnag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := map[string]time.Duration{
+		"":                              defaultRetryAfter,
+		"garbage":                       defaultRetryAfter,
+		"7":                             7 * time.Second,
+		"-3":                            0,
+		"3600":                          maxRetryAfter,
+		"Fri, 01 Jan 2021 00:00:20 GMT": 20 * time.Second,
+	}
+
+	for header, want := range cases {
+		if got := parseRetryAfter(header, now); got != want {
+			t.Errorf("parseRetryAfter(%q) = %s, want %s", header, got, want)
+		}
+	}
+}
+
+func TestDoRequestRetriesRateLimitedRequests(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if hits == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(429)
+			return
+		}
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	c := NewClient(ClientConfig{BaseURL: server.URL, OrganizationID: "org", MaxRateLimitRetries: 1})
+
+	r, err := c.testAuth(context.Background())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if r.StatusCode != 200 {
+		t.Fatalf("expected 200 after retrying, got %d", r.StatusCode)
+	}
+	if hits != 2 {
+		t.Fatalf("expected 2 requests, got %d", hits)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for retry := 0; retry < 40; retry++ {
+		d := backoff(retry)
+		if d < retryBaseDelay/2 || d > retryMaxDelay {
+			t.Errorf("backoff(%d) = %s, outside [%s, %s]", retry, d, retryBaseDelay/2, retryMaxDelay)
+		}
+	}
+}
+
+func TestDoRequestRetriesTransientErrors(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if hits == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	c := NewClient(ClientConfig{BaseURL: server.URL, OrganizationID: "org", MaxRetries: 1})
+
+	r, err := c.testAuth(context.Background())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if r.StatusCode != 200 {
+		t.Fatalf("expected 200 after retrying, got %d", r.StatusCode)
+	}
+	if hits != 2 {
+		t.Fatalf("expected 2 requests, got %d", hits)
+	}
+}
+
+func TestCreateProjectRetriesWithTheSameIdempotencyKey(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(idempotencyKeyHeader))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(201)
+		fmt.Fprint(w, `{"id": "1", "name": "api"}`)
+	}))
+	defer server.Close()
+
+	c := NewClient(ClientConfig{BaseURL: server.URL, OrganizationID: "org", MaxRetries: 1})
+
+	if _, err := c.CreateProject(context.Background(), &CreateProjectRequest{Name: "api", Type: "go"}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(keys) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(keys))
+	}
+	if keys[0] == "" || keys[0] != keys[1] {
+		t.Fatalf("expected both attempts to carry the same idempotency key, got %q", keys)
+	}
+
+	if _, err := c.CreateProject(context.Background(), &CreateProjectRequest{Name: "web", Type: "js"}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if keys[2] == keys[0] {
+		t.Fatalf("expected a new idempotency key for a new create")
+	}
+}
+
+func TestDoRequestRetriesNetworkErrorsOnlyWhenIdempotent(t *testing.T) {
+	attempts := 0
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, errors.New("connection reset")
+		}
+		return &http.Response{StatusCode: 200, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("")), Request: r}, nil
+	})
+
+	c := NewClient(ClientConfig{BaseURL: "https://bugsnag.example.com", OrganizationID: "org", MaxRetries: 1, Transport: transport})
+
+	req, _ := http.NewRequest("POST", "https://bugsnag.example.com/organizations/org/projects", nil)
+	if _, err := c.doRequest(req); err == nil {
+		t.Fatalf("expected a POST without an idempotency key not to be retried")
+	}
+
+	attempts = 0
+	req, _ = http.NewRequest("POST", "https://bugsnag.example.com/organizations/org/projects", nil)
+	setIdempotencyKey(req)
+	if _, err := c.doRequest(req); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected 2 attempts, got %d", attempts)
+	}
+}
+
+func TestDoRequestHonoursTheRetryBudget(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c := NewClient(ClientConfig{BaseURL: server.URL, OrganizationID: "org", MaxRetries: 2})
+
+	r, err := c.testAuth(context.Background())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if r.StatusCode != http.StatusServiceUnavailable || hits != 3 {
+		t.Fatalf("expected 3 attempts ending in a 503, got %d attempts ending in a %d", hits, r.StatusCode)
+	}
+
+	hits = 0
+	c = NewClient(ClientConfig{BaseURL: server.URL, OrganizationID: "org", MaxRetries: 5, MaxRetryElapsedTime: time.Millisecond})
+
+	if _, err := c.testAuth(context.Background()); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if hits != 1 {
+		t.Fatalf("expected no retries once the elapsed time budget is spent, got %d attempts", hits)
+	}
+}
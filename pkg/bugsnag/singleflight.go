@@ -0,0 +1,67 @@
+package bugsnag
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// requestGroup collapses concurrent identical reads into a single request, in
+// the manner of golang.org/x/sync/singleflight: when many data sources read
+// the same project list during a plan, only the first caller reaches the API
+// and the others share its result.
+//
+// Shared results must be treated as read-only by every caller.
+type requestGroup struct {
+	mu    sync.Mutex
+	calls map[string]*requestGroupCall
+}
+
+type requestGroupCall struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+func newRequestGroup() *requestGroup {
+	return &requestGroup{calls: make(map[string]*requestGroupCall)}
+}
+
+// do calls fn and returns its result, unless a call with the same key is
+// already in flight, in which case it waits for that call's result instead.
+func (g *requestGroup) do(ctx context.Context, key string, fn func() (interface{}, error)) (interface{}, error) {
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-call.done:
+		}
+
+		// the call was cut short by its own caller's context, which says
+		// nothing about whether this caller's request would succeed
+		if isContextError(call.err) && ctx.Err() == nil {
+			return fn()
+		}
+		return call.value, call.err
+	}
+
+	call := &requestGroupCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.value, call.err = fn()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(call.done)
+
+	return call.value, call.err
+}
+
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
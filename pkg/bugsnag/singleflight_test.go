@@ -0,0 +1,88 @@
+package bugsnag
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientCollapsesConcurrentReads(t *testing.T) {
+	var requests int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		fmt.Fprint(w, `{"id": "1", "name": "api"}`)
+	}))
+	defer server.Close()
+
+	c := NewClient(ClientConfig{BaseURL: server.URL, OrganizationID: "1"})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			project, err := c.GetProject(context.Background(), "1")
+			if err == nil && project.Name != "api" {
+				err = fmt.Errorf("unexpected project: %+v", project)
+			}
+			errs <- err
+		}()
+	}
+
+	// give every reader time to join the request in flight
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("expected 1 request, got %d", requests)
+	}
+}
+
+func TestRequestGroupIgnoresOtherCallersCancellation(t *testing.T) {
+	g := newRequestGroup()
+
+	started, release := make(chan struct{}), make(chan struct{})
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	go func() {
+		_, _ = g.do(leaderCtx, "key", func() (interface{}, error) {
+			close(started)
+			<-release
+			return nil, leaderCtx.Err()
+		})
+	}()
+	<-started
+
+	result := make(chan interface{})
+	go func() {
+		value, err := g.do(context.Background(), "key", func() (interface{}, error) {
+			return "fresh", nil
+		})
+		if err != nil {
+			value = err
+		}
+		result <- value
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	close(release)
+
+	if value := <-result; value != "fresh" {
+		t.Fatalf("expected the follower to make its own request, got %v", value)
+	}
+}
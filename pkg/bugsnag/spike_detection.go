@@ -0,0 +1,30 @@
+package bugsnag
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetSpikeDetectionSettings returns when Bugsnag considers the errors of a
+// project to be spiking.
+func (c *Client) GetSpikeDetectionSettings(ctx context.Context, projectID string) (*SpikeDetectionSettings, error) {
+	settings := &SpikeDetectionSettings{}
+	url := fmt.Sprintf("%s/projects/%s/spike_detection", c.HostURL, projectID)
+	if err := c.doJSON(ctx, "getSpikeDetectionSettings", "GET", url, nil, settings, 200); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// UpdateSpikeDetectionSettings replaces the spike detection settings of a
+// project, and returns them as updated.
+func (c *Client) UpdateSpikeDetectionSettings(ctx context.Context, projectID string, settings *SpikeDetectionSettings) (*SpikeDetectionSettings, error) {
+	updated := &SpikeDetectionSettings{}
+	url := fmt.Sprintf("%s/projects/%s/spike_detection", c.HostURL, projectID)
+	if err := c.doJSON(ctx, "updateSpikeDetectionSettings", "PATCH", url, settings, updated, 200); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,37 @@
+package bugsnag
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetSSOConfiguration returns the SSO configuration of the organization, or
+// ErrNotFound if it doesn't use SSO.
+func (c *Client) GetSSOConfiguration(ctx context.Context) (*SSOConfiguration, error) {
+	configuration := &SSOConfiguration{}
+	endpoint := fmt.Sprintf("%s/saml_configuration", c.HostURL)
+	if err := c.doJSON(ctx, "getSSOConfiguration", "GET", endpoint, nil, configuration, 200); err != nil {
+		return nil, err
+	}
+
+	return configuration, nil
+}
+
+// UpdateSSOConfiguration sets the SSO configuration of the organization,
+// enabling SSO if it wasn't, and returns the configuration.
+func (c *Client) UpdateSSOConfiguration(ctx context.Context, configuration *SSOConfiguration) (*SSOConfiguration, error) {
+	updated := &SSOConfiguration{}
+	endpoint := fmt.Sprintf("%s/saml_configuration", c.HostURL)
+	if err := c.doJSON(ctx, "updateSSOConfiguration", "PUT", endpoint, configuration, updated, 200, 201); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeleteSSOConfiguration disables SSO for the organization, so that its
+// members sign in with a password again.
+func (c *Client) DeleteSSOConfiguration(ctx context.Context) error {
+	endpoint := fmt.Sprintf("%s/saml_configuration", c.HostURL)
+	return c.doJSON(ctx, "deleteSSOConfiguration", "DELETE", endpoint, nil, nil, 200, 204)
+}
@@ -0,0 +1,52 @@
+package bugsnag
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetStabilityTargets returns the stability targets of a project.
+func (c *Client) GetStabilityTargets(ctx context.Context, projectID string) (*StabilityTargets, error) {
+	targets := &StabilityTargets{}
+	url := fmt.Sprintf("%s/projects/%s/stability_targets", c.HostURL, projectID)
+	if err := c.doJSON(ctx, "getStabilityTargets", "GET", url, nil, targets, 200); err != nil {
+		return nil, err
+	}
+
+	return targets, nil
+}
+
+// UpdateStabilityTargets updates the stability targets of a project set in
+// targets, and returns them all as updated.
+func (c *Client) UpdateStabilityTargets(ctx context.Context, projectID string, targets *StabilityTargets) (*StabilityTargets, error) {
+	updated := &StabilityTargets{}
+	url := fmt.Sprintf("%s/projects/%s/stability_targets", c.HostURL, projectID)
+	if err := c.doJSON(ctx, "updateStabilityTargets", "PATCH", url, targets, updated, 200); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// GetEventUsage returns the event usage of a project in the current billing
+// period.
+func (c *Client) GetEventUsage(ctx context.Context, projectID string) (*EventUsage, error) {
+	usage := &EventUsage{}
+	url := fmt.Sprintf("%s/projects/%s/event_usage", c.HostURL, projectID)
+	if err := c.doJSON(ctx, "getEventUsage", "GET", url, nil, usage, 200); err != nil {
+		return nil, err
+	}
+
+	return usage, nil
+}
+
+// GetProjectStability returns the current stability of a project.
+func (c *Client) GetProjectStability(ctx context.Context, projectID string) (*ProjectStability, error) {
+	stability := &ProjectStability{}
+	url := fmt.Sprintf("%s/projects/%s/stability", c.HostURL, projectID)
+	if err := c.doJSON(ctx, "getProjectStability", "GET", url, nil, stability, 200); err != nil {
+		return nil, err
+	}
+
+	return stability, nil
+}
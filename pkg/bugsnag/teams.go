@@ -0,0 +1,41 @@
+package bugsnag
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// SetTeamProject gives the members of a team access to a project with the
+// given permission, or changes the permission they have, and returns the
+// access.
+func (c *Client) SetTeamProject(ctx context.Context, teamID, projectID, permission string) (*TeamProject, error) {
+	access := &TeamProject{}
+	endpoint := fmt.Sprintf("%s/teams/%s/projects/%s", c.HostURL, url.PathEscape(teamID), url.PathEscape(projectID))
+	if err := c.doJSON(ctx, "setTeamProject", "PUT", endpoint, map[string]interface{}{
+		"permission": permission,
+	}, access, 200, 201); err != nil {
+		return nil, err
+	}
+
+	return access, nil
+}
+
+// GetTeamProject returns the access the members of a team have to a
+// project, or ErrNotFound if the team can't access it.
+func (c *Client) GetTeamProject(ctx context.Context, teamID, projectID string) (*TeamProject, error) {
+	access := &TeamProject{}
+	endpoint := fmt.Sprintf("%s/teams/%s/projects/%s", c.HostURL, url.PathEscape(teamID), url.PathEscape(projectID))
+	if err := c.doJSON(ctx, "getTeamProject", "GET", endpoint, nil, access, 200); err != nil {
+		return nil, err
+	}
+
+	return access, nil
+}
+
+// RemoveTeamProject revokes the access the members of a team have to a
+// project. Members who can access the project otherwise keep their access.
+func (c *Client) RemoveTeamProject(ctx context.Context, teamID, projectID string) error {
+	endpoint := fmt.Sprintf("%s/teams/%s/projects/%s", c.HostURL, url.PathEscape(teamID), url.PathEscape(projectID))
+	return c.doJSON(ctx, "removeTeamProject", "DELETE", endpoint, nil, nil, 200, 204)
+}
@@ -0,0 +1,266 @@
+package bugsnag
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OTLP span kinds and status codes, see
+// https://github.com/open-telemetry/opentelemetry-proto/blob/main/opentelemetry/proto/trace/v1/trace.proto
+const (
+	spanKindInternal = 1
+	spanKindClient   = 3
+
+	spanStatusError = 2
+)
+
+// traceQueueSize bounds the traces waiting to be exported; traces finished
+// while it is full, e.g. with an unreachable collector, are dropped.
+const traceQueueSize = 64
+
+// tracer records a span around every client operation and its HTTP requests,
+// and exports them to an OTLP/HTTP collector using the JSON encoding. Traces
+// are exported in the background, so that a slow collector never holds up
+// an operation. A nil tracer records nothing.
+type tracer struct {
+	endpoint string
+	version  string
+	client   *http.Client
+
+	queue   chan []*span
+	pending sync.WaitGroup
+
+	// parent is taken from the TRACEPARENT environment variable, so spans
+	// join the trace of the CI job running Terraform.
+	parentTraceID string
+	parentSpanID  string
+}
+
+// newTracer returns a tracer exporting to the given OTLP/HTTP endpoint, or
+// nil when endpoint is empty.
+func newTracer(endpoint, version string) *tracer {
+	if endpoint == "" {
+		return nil
+	}
+
+	t := &tracer{
+		endpoint: strings.TrimSuffix(endpoint, "/") + "/v1/traces",
+		version:  version,
+		client:   &http.Client{Timeout: 5 * time.Second},
+		queue:    make(chan []*span, traceQueueSize),
+	}
+	go t.run()
+
+	// traceparent: version-traceid-spanid-flags
+	if parts := strings.Split(os.Getenv("TRACEPARENT"), "-"); len(parts) == 4 && len(parts[1]) == 32 && len(parts[2]) == 16 {
+		t.parentTraceID, t.parentSpanID = parts[1], parts[2]
+	}
+
+	return t
+}
+
+type span struct {
+	tracer       *tracer
+	traceID      string
+	spanID       string
+	parentSpanID string
+	name         string
+	kind         int
+	start        time.Time
+	end          time.Time
+	attributes   map[string]interface{}
+	errMessage   string
+
+	// root collects the finished spans of a trace so they are exported
+	// together when the root span ends.
+	root     *span
+	mu       sync.Mutex
+	children []*span
+}
+
+type spanContextKey struct{}
+
+func spanFromContext(ctx context.Context) *span {
+	s, _ := ctx.Value(spanContextKey{}).(*span)
+	return s
+}
+
+// start begins a span as a child of the span in ctx, if any, and returns a
+// context carrying the new span.
+func (t *tracer) start(ctx context.Context, name string, kind int) (context.Context, *span) {
+	if t == nil {
+		return ctx, nil
+	}
+
+	s := &span{
+		tracer:     t,
+		spanID:     randomHex(8),
+		name:       name,
+		kind:       kind,
+		start:      time.Now(),
+		attributes: map[string]interface{}{},
+	}
+
+	if parent := spanFromContext(ctx); parent != nil {
+		s.traceID = parent.traceID
+		s.parentSpanID = parent.spanID
+		s.root = parent.root
+	} else {
+		s.traceID, s.parentSpanID = t.parentTraceID, t.parentSpanID
+		if s.traceID == "" {
+			s.traceID = randomHex(16)
+		}
+		s.root = s
+	}
+
+	return context.WithValue(ctx, spanContextKey{}, s), s
+}
+
+func (s *span) setAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.attributes[key] = value
+}
+
+func (s *span) setError(message string) {
+	if s == nil {
+		return
+	}
+	s.errMessage = message
+}
+
+// traceparent returns the W3C trace context header value for the span.
+func (s *span) traceparent() string {
+	return fmt.Sprintf("00-%s-%s-01", s.traceID, s.spanID)
+}
+
+// finish ends the span. Ending a root span exports the whole trace.
+func (s *span) finish() {
+	if s == nil {
+		return
+	}
+	s.end = time.Now()
+
+	s.root.mu.Lock()
+	s.root.children = append(s.root.children, s)
+	spans := s.root.children
+	s.root.mu.Unlock()
+
+	if s == s.root {
+		s.tracer.enqueue(spans)
+	}
+}
+
+// enqueue hands a finished trace over to the exporting goroutine.
+func (t *tracer) enqueue(spans []*span) {
+	t.pending.Add(1)
+	select {
+	case t.queue <- spans:
+	default:
+		t.pending.Done()
+		log.Printf("[WARN] dropping a trace, %d are already waiting to be exported to %s", traceQueueSize, t.endpoint)
+	}
+}
+
+// run exports the queued traces, one at a time.
+func (t *tracer) run() {
+	for spans := range t.queue {
+		t.export(spans)
+		t.pending.Done()
+	}
+}
+
+// flush waits for the traces finished so far to be exported.
+func (t *tracer) flush() {
+	if t == nil {
+		return
+	}
+	t.pending.Wait()
+}
+
+func (t *tracer) export(spans []*span) {
+	otlpSpans := make([]map[string]interface{}, 0, len(spans))
+	for _, s := range spans {
+		otlpSpan := map[string]interface{}{
+			"traceId":           s.traceID,
+			"spanId":            s.spanID,
+			"name":              s.name,
+			"kind":              s.kind,
+			"startTimeUnixNano": strconv.FormatInt(s.start.UnixNano(), 10),
+			"endTimeUnixNano":   strconv.FormatInt(s.end.UnixNano(), 10),
+			"attributes":        otlpAttributes(s.attributes),
+		}
+		if s.parentSpanID != "" {
+			otlpSpan["parentSpanId"] = s.parentSpanID
+		}
+		if s.errMessage != "" {
+			otlpSpan["status"] = map[string]interface{}{"code": spanStatusError, "message": s.errMessage}
+		}
+		otlpSpans = append(otlpSpans, otlpSpan)
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"resourceSpans": []interface{}{map[string]interface{}{
+			"resource": map[string]interface{}{
+				"attributes": otlpAttributes(map[string]interface{}{
+					"service.name":    "terraform-provider-bugsnag",
+					"service.version": t.version,
+				}),
+			},
+			"scopeSpans": []interface{}{map[string]interface{}{
+				"scope": map[string]interface{}{"name": "terraform-provider-bugsnag", "version": t.version},
+				"spans": otlpSpans,
+			}},
+		}},
+	})
+	if err != nil {
+		log.Printf("[WARN] unable to encode trace: %s", err)
+		return
+	}
+
+	r, err := t.client.Post(t.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		log.Printf("[WARN] unable to export trace to %s: %s", t.endpoint, err)
+		return
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode >= 300 {
+		log.Printf("[WARN] unable to export trace to %s: HTTP %d", t.endpoint, r.StatusCode)
+	}
+}
+
+func otlpAttributes(attributes map[string]interface{}) []interface{} {
+	result := make([]interface{}, 0, len(attributes))
+	for k, v := range attributes {
+		var value map[string]interface{}
+		switch v := v.(type) {
+		case int:
+			value = map[string]interface{}{"intValue": strconv.Itoa(v)}
+		case bool:
+			value = map[string]interface{}{"boolValue": v}
+		default:
+			value = map[string]interface{}{"stringValue": fmt.Sprint(v)}
+		}
+		result = append(result, map[string]interface{}{"key": k, "value": value})
+	}
+	return result
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
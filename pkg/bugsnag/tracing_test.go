@@ -0,0 +1,93 @@
+package bugsnag
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClientExportsOperationSpans(t *testing.T) {
+	var exported struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []struct {
+					TraceID      string `json:"traceId"`
+					SpanID       string `json:"spanId"`
+					ParentSpanID string `json:"parentSpanId"`
+					Name         string `json:"name"`
+				} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" {
+			t.Errorf("unexpected export to %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&exported); err != nil {
+			t.Errorf("decoding export: %s", err)
+		}
+	}))
+	defer collector.Close()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("traceparent") == "" {
+			t.Errorf("expected a traceparent header on API requests")
+		}
+	}))
+	defer api.Close()
+
+	c := NewClient(ClientConfig{BaseURL: api.URL, OrganizationID: "org", OTLPEndpoint: collector.URL})
+	if _, err := c.testAuth(context.Background()); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	c.tracer.flush()
+
+	if len(exported.ResourceSpans) != 1 || len(exported.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf("expected a single batch of spans, got %+v", exported)
+	}
+	spans := exported.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("expected an operation span and a request span, got %+v", spans)
+	}
+
+	request, operation := spans[0], spans[1]
+	if operation.Name != "bugsnag.testAuth" || request.Name != "HTTP GET" {
+		t.Fatalf("unexpected span names %q and %q", operation.Name, request.Name)
+	}
+	if request.ParentSpanID != operation.SpanID || request.TraceID != operation.TraceID {
+		t.Fatalf("expected the request span to be a child of the operation span")
+	}
+}
+
+func TestClientExportsSpansInTheBackground(t *testing.T) {
+	release := make(chan struct{})
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer collector.Close()
+	defer close(release)
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer api.Close()
+
+	c := NewClient(ClientConfig{BaseURL: api.URL, OrganizationID: "org", OTLPEndpoint: collector.URL})
+
+	done := make(chan error)
+	go func() {
+		_, err := c.testAuth(context.Background())
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected the operation not to wait for the collector")
+	}
+}
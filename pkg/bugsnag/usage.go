@@ -0,0 +1,90 @@
+package bugsnag
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// idPathSegment matches the object IDs in API paths, so requests for
+// different projects are counted against the same endpoint.
+var idPathSegment = regexp.MustCompile(`^[0-9a-f]{24}$`)
+
+// usageTracker counts API requests per endpoint, for the summary reported
+// at the end of each operation.
+type usageTracker struct {
+	mu        sync.Mutex
+	calls     map[string]int
+	rateLimit *rateLimitTracker
+}
+
+// newUsageTracker returns a tracker, or nil (counting nothing) when the
+// summary is not enabled.
+func newUsageTracker(enabled bool, rateLimit *rateLimitTracker) *usageTracker {
+	if !enabled {
+		return nil
+	}
+	return &usageTracker{
+		calls:     map[string]int{},
+		rateLimit: rateLimit,
+	}
+}
+
+// record counts a request.
+func (u *usageTracker) record(req *http.Request) {
+	if u == nil {
+		return
+	}
+
+	segments := strings.Split(req.URL.Path, "/")
+	for i, segment := range segments {
+		if idPathSegment.MatchString(segment) {
+			segments[i] = "{id}"
+		}
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.calls[req.Method+" "+strings.Join(segments, "/")]++
+}
+
+// summary describes the requests sent so far, e.g.
+// "Bugsnag API usage: 42 GETs, 7 POSTs, peak rate-limit usage 80%".
+func (u *usageTracker) summary() string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	methods := map[string]int{}
+	endpoints := make([]string, 0, len(u.calls))
+	for endpoint, n := range u.calls {
+		methods[strings.SplitN(endpoint, " ", 2)[0]] += n
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+
+	methodNames := make([]string, 0, len(methods))
+	for method := range methods {
+		methodNames = append(methodNames, method)
+	}
+	sort.Strings(methodNames)
+
+	totals := make([]string, 0, len(methods))
+	for _, method := range methodNames {
+		totals = append(totals, fmt.Sprintf("%d %ss", methods[method], method))
+	}
+
+	summary := "Bugsnag API usage: " + strings.Join(totals, ", ")
+	if peak, ok := u.rateLimit.peakUsage(); ok {
+		summary += fmt.Sprintf(", peak rate-limit usage %.0f%%", peak*100)
+	}
+
+	for _, endpoint := range endpoints {
+		summary += fmt.Sprintf("\n  %s: %d", endpoint, u.calls[endpoint])
+	}
+
+	return summary
+}
@@ -0,0 +1,67 @@
+package bugsnag
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-bugsnag/internal/bugsnagtest"
+)
+
+func TestUsageTrackerSummary(t *testing.T) {
+	rateLimit := newRateLimitTracker(0)
+	u := newUsageTracker(true, rateLimit)
+
+	for _, path := range []string{
+		"/organizations/515fb9337c1074f6fd000001/projects",
+		"/organizations/515fb9337c1074f6fd000001/projects",
+		"/organizations/515fb9337c1074f6fd000001/projects/515fb9337c1074f6fd000002",
+	} {
+		req, _ := http.NewRequest("GET", "https://api.bugsnag.com"+path, nil)
+		u.record(req)
+	}
+	req, _ := http.NewRequest("POST", "https://api.bugsnag.com/organizations/515fb9337c1074f6fd000001/projects", nil)
+	u.record(req)
+
+	rateLimit.observe(http.Header{
+		"X-Ratelimit-Limit":     []string{"10"},
+		"X-Ratelimit-Remaining": []string{"2"},
+	})
+
+	summary := u.summary()
+	for _, want := range []string{
+		"Bugsnag API usage: 3 GETs, 1 POSTs, peak rate-limit usage 80%",
+		"GET /organizations/{id}/projects: 2",
+		"GET /organizations/{id}/projects/{id}: 1",
+		"POST /organizations/{id}/projects: 1",
+	} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("expected summary to contain %q, got:\n%s", want, summary)
+		}
+	}
+}
+
+func TestClientUsageSummary(t *testing.T) {
+	server := bugsnagtest.NewServer()
+	defer server.Close()
+
+	c := NewClient(ClientConfig{BaseURL: server.URL, OrganizationID: bugsnagtest.OrganizationID, UsageSummary: true})
+	if _, ok := c.UsageSummary(); ok {
+		t.Fatalf("expected no summary before any request")
+	}
+	if _, err := c.ListProjects(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if summary, ok := c.UsageSummary(); !ok || !strings.Contains(summary, "GET /organizations/{id}/projects: 1") {
+		t.Fatalf("expected the request to be summarized, got %q", summary)
+	}
+
+	c = NewClient(ClientConfig{BaseURL: server.URL, OrganizationID: bugsnagtest.OrganizationID})
+	if _, err := c.ListProjects(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, ok := c.UsageSummary(); ok {
+		t.Fatalf("expected no summary without UsageSummary")
+	}
+}
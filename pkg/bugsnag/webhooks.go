@@ -0,0 +1,47 @@
+package bugsnag
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// CreateWebhook adds a webhook to a project, forwarding its events to a URL.
+func (c *Client) CreateWebhook(ctx context.Context, projectID string, request *CreateWebhookRequest) (*Webhook, error) {
+	webhook := &Webhook{}
+	endpoint := fmt.Sprintf("%s/projects/%s/webhooks", c.HostURL, url.PathEscape(projectID))
+	if err := c.doJSON(ctx, "createWebhook", "POST", endpoint, request, webhook, 200, 201); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// GetWebhook returns the webhook of a project with the given ID.
+func (c *Client) GetWebhook(ctx context.Context, projectID, webhookID string) (*Webhook, error) {
+	webhook := &Webhook{}
+	endpoint := fmt.Sprintf("%s/projects/%s/webhooks/%s", c.HostURL, url.PathEscape(projectID), url.PathEscape(webhookID))
+	if err := c.doJSON(ctx, "getWebhook", "GET", endpoint, nil, webhook, 200); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// UpdateWebhook changes the attributes of a webhook set in request, and
+// returns the updated webhook.
+func (c *Client) UpdateWebhook(ctx context.Context, projectID, webhookID string, request *UpdateWebhookRequest) (*Webhook, error) {
+	webhook := &Webhook{}
+	endpoint := fmt.Sprintf("%s/projects/%s/webhooks/%s", c.HostURL, url.PathEscape(projectID), url.PathEscape(webhookID))
+	if err := c.doJSON(ctx, "updateWebhook", "PATCH", endpoint, request, webhook, 200); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// DeleteWebhook stops a webhook forwarding the events of a project.
+func (c *Client) DeleteWebhook(ctx context.Context, projectID, webhookID string) error {
+	endpoint := fmt.Sprintf("%s/projects/%s/webhooks/%s", c.HostURL, url.PathEscape(projectID), url.PathEscape(webhookID))
+	return c.doJSON(ctx, "deleteWebhook", "DELETE", endpoint, nil, nil, 200, 204)
+}